@@ -0,0 +1,210 @@
+package sunspec
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"strings"
+)
+
+// Role is the level of access granted to a client.
+type Role uint8
+
+const (
+	// ReadOnly permits reading point values.
+	ReadOnly Role = iota + 1
+	// ReadWrite permits reading and writing point values.
+	ReadWrite
+)
+
+// String formats the role as string.
+func (r Role) String() string {
+	switch r {
+	case ReadOnly:
+		return "read-only"
+	case ReadWrite:
+		return "read-write"
+	}
+	return fmt.Sprintf("role(%d)", uint8(r))
+}
+
+// Rule grants access to all requests matching its criteria.
+type Rule struct {
+	// Networks restricts the rule to clients with the given ip addresses or from the given CIDR networks,
+	// e.g. "192.168.0.10" or "10.0.0.0/8". If empty, the rule applies to all clients.
+	Networks []string
+	// Roles restricts the rule to clients authenticated by a certificate carrying one of the given roles,
+	// see Security. If empty, the rule applies to all clients.
+	Roles []string
+	// Units restricts the rule to requests addressing one of the given unit ids.
+	// If empty, the rule applies to all unit ids.
+	Units []byte
+	// Role is the access granted by the rule.
+	Role Role
+	// Models restricts write access to the models with the given ids.
+	// If empty, all models may be written. Only applicable for the ReadWrite role.
+	Models []uint16
+}
+
+// Policy controls the access of clients to the server.
+// The rules are evaluated in order for each request before calling the handler,
+// the first rule matching the client, its role and the unit id decides on the access.
+// Requests without any matching rule are denied.
+// Denied requests are answered with modbus.IllegalFunction.
+type Policy struct {
+	Rules []Rule
+	// Logger records all denied requests, if nil the standard logger is used.
+	Logger *log.Logger
+}
+
+// policy is the compiled form of a Policy.
+type policy struct {
+	rules  []rule
+	logger *log.Logger
+}
+
+// rule is the compiled form of a Rule.
+type rule struct {
+	Rule
+	networks []*net.IPNet
+}
+
+// compile parses the policy´s networks.
+func (p *Policy) compile() (*policy, error) {
+	c := &policy{logger: p.Logger, rules: make([]rule, len(p.Rules))}
+	if c.logger == nil {
+		c.logger = log.Default()
+	}
+	for i, r := range p.Rules {
+		if r.Role != ReadOnly && r.Role != ReadWrite {
+			return nil, fmt.Errorf("sunspec: rule %v has an invalid role %v", i, r.Role)
+		}
+		c.rules[i].Rule = r
+		for _, n := range r.Networks {
+			if strings.Contains(n, "/") {
+				_, ipn, err := net.ParseCIDR(n)
+				if err != nil {
+					return nil, fmt.Errorf("sunspec: rule %v has an invalid network: %w", i, err)
+				}
+				c.rules[i].networks = append(c.rules[i].networks, ipn)
+				continue
+			}
+			ip := net.ParseIP(n)
+			if ip == nil {
+				return nil, fmt.Errorf("sunspec: rule %v has an invalid ip address %q", i, n)
+			}
+			// a single address is treated as network containing only itself
+			bits := 8 * len(ip)
+			if ip4 := ip.To4(); ip4 != nil {
+				ip, bits = ip4, 32
+			}
+			c.rules[i].networks = append(c.rules[i].networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+		}
+	}
+	return c, nil
+}
+
+// match reports whether the rule applies to a request from the peer addressing unit uid.
+func (r *rule) match(p Peer, uid byte) bool {
+	if len(r.Roles) > 0 {
+		var ok bool
+		for _, role := range r.Roles {
+			ok = ok || (p.Role != "" && role == p.Role)
+		}
+		if !ok {
+			return false
+		}
+	}
+	if len(r.Units) > 0 {
+		var ok bool
+		for _, u := range r.Units {
+			ok = ok || u == uid
+		}
+		if !ok {
+			return false
+		}
+	}
+	if len(r.networks) == 0 {
+		return true
+	}
+	ip := ipOf(p.Addr)
+	for _, n := range r.networks {
+		if ip != nil && n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// writable reports whether the rule permits writing the model.
+func (r *rule) writable(m Model) bool {
+	if r.Role != ReadWrite {
+		return false
+	}
+	if len(r.Models) == 0 {
+		return true
+	}
+	if m == nil || m.ID() == nil {
+		return false
+	}
+	for _, id := range r.Models {
+		if id == m.ID().Get() {
+			return true
+		}
+	}
+	return false
+}
+
+// authorize evaluates the policy for the request addressing the registers idx,
+// returning an error wrapping ErrAccessDenied if it is refused.
+// Denials are written to the policy´s logger.
+func (p *policy) authorize(r *request, idx Index) error {
+	err := p.evaluate(r, idx)
+	if err != nil {
+		op := "read"
+		if r.writing {
+			op = "write"
+		}
+		p.logger.Printf("sunspec: denied %v of %v registers at address %v by %v (unit %v): %v",
+			op, idx.Quantity(), idx.Address(), r.peer.Addr, r.unit, err)
+	}
+	return err
+}
+
+// evaluate determines the rule applicable to the request and checks its permissions
+// for all models of the layout overlapping the registers idx.
+func (p *policy) evaluate(r *request, idx Index) error {
+	for i := range p.rules {
+		rl := &p.rules[i]
+		if !rl.match(r.peer, r.unit) {
+			continue
+		}
+		if !r.writing {
+			return nil
+		}
+		for _, m := range r.layout {
+			if intersect(m, idx) && !rl.writable(m) {
+				return fmt.Errorf("%w: no write permission for model %q", ErrAccessDenied, m.Name())
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: no matching rule", ErrAccessDenied)
+}
+
+// ipOf returns the ip address of the network address, or nil if it has none.
+func ipOf(addr net.Addr) net.IP {
+	switch a := addr.(type) {
+	case *net.TCPAddr:
+		return a.IP
+	case *net.UDPAddr:
+		return a.IP
+	case nil:
+		return nil
+	}
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		host = addr.String()
+	}
+	return net.ParseIP(host)
+}
@@ -0,0 +1,121 @@
+package sunspec
+
+import (
+	"errors"
+	"math"
+)
+
+// Delta is the increment of an accumulator between two successive readings.
+type Delta struct {
+	// Raw is the increment of the underlying counter.
+	Raw uint64
+	// Value is the increment scaled by the point´s factor, e.g. in Wh.
+	Value float64
+	// Rollover specifies whether the counter wrapped around its maximum.
+	Rollover bool
+	// Reset specifies whether the counter was restarted, e.g. by a reboot of the device.
+	// The increment is then counted from zero.
+	Reset bool
+}
+
+// Tracker computes the increments of an accumulator point (acc16, acc32 or acc64) from its successive readings.
+// Decreasing readings are considered as rollover of the counter only if the wrapped increment
+// lies within the margin, i.e. the previous reading was close to the counter´s maximum.
+// All other decreases are considered as reset, so that a restarted device does not yield an increment
+// spanning the counter´s range.
+// Readings of unimplemented values are ignored. A tracker must not be used concurrently.
+//
+//	t, _ := sunspec.NewTracker(c.Model(103).Point("WH"))
+//	for {
+//		c.Read(ctx, t.Point())
+//		d := t.Update()
+//		bill(d.Value)
+//	}
+type Tracker struct {
+	// Margin is the largest wrapped increment considered as rollover, as fraction of the counter´s range.
+	// Zero defaults to 0.1.
+	Margin float64
+
+	p       Point
+	bits    uint
+	last    uint64
+	started bool
+	total   float64
+}
+
+// NewTracker returns a tracker for the accumulator point p.
+// The first update establishes the baseline, subsequent ones yield the increments.
+func NewTracker(p Point) (*Tracker, error) {
+	t := &Tracker{p: p}
+	// the concrete types are distinguished, as the interfaces are shared with the unsigned integers
+	switch p.(type) {
+	case *tAcc16:
+		t.bits = 16
+	case *tAcc32:
+		t.bits = 32
+	case *tAcc64:
+		t.bits = 64
+	default:
+		return nil, errors.New("sunspec: tracked point is not an accumulator")
+	}
+	return t, nil
+}
+
+// Point returns the tracked point.
+func (t *Tracker) Point() Point { return t.p }
+
+// Total returns the sum of all scaled increments since the tracker was created.
+func (t *Tracker) Total() float64 { return t.total }
+
+// Update takes the current value of the point, returning its increment since the previous update.
+// The point´s value must have been read beforehand.
+func (t *Tracker) Update() Delta {
+	if !t.p.Valid() {
+		return Delta{}
+	}
+	var (
+		v uint64
+		f int16
+	)
+	switch p := t.p.(type) {
+	case *tAcc16:
+		v, f = uint64(p.Get()), p.Factor()
+	case *tAcc32:
+		v, f = uint64(p.Get()), p.Factor()
+	case *tAcc64:
+		v, f = p.Get(), p.Factor()
+	}
+	if !t.started {
+		t.last, t.started = v, true
+		return Delta{}
+	}
+	var d Delta
+	switch wrapped := (v - t.last) & mask(t.bits); {
+	case v >= t.last:
+		d.Raw = v - t.last
+	case float64(wrapped) <= t.margin()*float64(mask(t.bits)):
+		d.Raw, d.Rollover = wrapped, true
+	default:
+		d.Raw, d.Reset = v, true
+	}
+	t.last = v
+	d.Value = float64(d.Raw) * math.Pow10(int(f))
+	t.total += d.Value
+	return d
+}
+
+// margin returns the configured margin or its default.
+func (t *Tracker) margin() float64 {
+	if t.Margin <= 0 {
+		return 0.1
+	}
+	return t.Margin
+}
+
+// mask returns the bit mask of a counter with the given number of bits.
+func mask(bits uint) uint64 {
+	if bits >= 64 {
+		return math.MaxUint64
+	}
+	return 1<<bits - 1
+}
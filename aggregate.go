@@ -0,0 +1,423 @@
+package sunspec
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/GoAethereal/cancel"
+)
+
+// Aggregation computes the value of the virtual point p from the corresponding points of the members.
+// The member points are given in the order of Aggregate.Members, those of members not implementing the point are nil.
+type Aggregation func(p Point, members Points) error
+
+// Distribution sets the values of the corresponding member points from the value of the virtual point p.
+// The member points are given in the order of Aggregate.Members, those of members not implementing the point are nil.
+type Distribution func(p Point, members Points) error
+
+// Aggregate is a server handler representing several member devices as a single virtual device.
+// Points bound to an aggregation are computed from the member devices on each read,
+// writes of points bound to a distribution are fanned out to the members.
+// All other points are served from memory.
+//
+//	a := &sunspec.Aggregate{Members: []*sunspec.Client{c1, c2}}
+//	a.Compute(s, "103/W", sunspec.Sum)
+//	a.Distribute(s, "123/WMaxLimPct", sunspec.Broadcast)
+//	s.Serve(ctx, a.Handle)
+type Aggregate struct {
+	// Members are the physical devices combined by the aggregate.
+	// The clients must have scanned their devices, so that the points can be resolved.
+	Members []*Client
+
+	mtx      sync.Mutex
+	bindings map[Point]*binding
+	locks    map[*Client]*sync.Mutex
+	// values guards the points of the virtual device, it is never held during member I/O.
+	values sync.Mutex
+}
+
+// binding associates a point of the virtual device with the rules applied to it.
+type binding struct {
+	path       string
+	aggregate  Aggregation
+	distribute Distribution
+}
+
+// Compute binds the aggregation fn to the point identified by the path, see Device.Resolve.
+// The path is used to identify the point both in the virtual device d and the members.
+func (a *Aggregate) Compute(d Device, path string, fn Aggregation) error {
+	return a.bind(d, path, func(b *binding) { b.aggregate = fn })
+}
+
+// Distribute binds the distribution fn to the point identified by the path, see Device.Resolve.
+// The path is used to identify the point both in the virtual device d and the members.
+func (a *Aggregate) Distribute(d Device, path string, fn Distribution) error {
+	return a.bind(d, path, func(b *binding) { b.distribute = fn })
+}
+
+func (a *Aggregate) bind(d Device, path string, set func(b *binding)) error {
+	idx, err := d.Resolve(path)
+	if err != nil {
+		return err
+	}
+	p, ok := idx.(Point)
+	if !ok {
+		return fmt.Errorf("sunspec: path %q does not identify a point", path)
+	}
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	if a.bindings == nil {
+		a.bindings = make(map[Point]*binding)
+	}
+	b := a.bindings[p]
+	if b == nil {
+		b = &binding{path: path}
+		a.bindings[p] = b
+	}
+	set(b)
+	return nil
+}
+
+// members resolves the path in all member devices, returning the points per member.
+// Members not implementing the point are omitted.
+func (a *Aggregate) members(path string) map[*Client]Point {
+	pts := make(map[*Client]Point, len(a.Members))
+	for _, c := range a.Members {
+		if c.Device == nil {
+			continue
+		}
+		if idx, err := c.Resolve(path); err == nil {
+			if p, ok := idx.(Point); ok {
+				pts[c] = p
+			}
+		}
+	}
+	return pts
+}
+
+// Handle processes the request, reading from and writing to the members as required.
+// The lock of a member is only held during its transactions, hence requests are processed concurrently.
+func (a *Aggregate) Handle(ctx cancel.Context, req Request) error {
+	if req.Writing() {
+		return a.write(ctx, req)
+	}
+	return a.read(ctx, req)
+}
+
+// bound returns a copy of the binding of the point p.
+func (a *Aggregate) bound(p Point) (binding, bool) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	if b := a.bindings[p]; b != nil {
+		return *b, true
+	}
+	return binding{}, false
+}
+
+// lock returns the mutex guarding the transactions with the member c and its point values.
+func (a *Aggregate) lock(c *Client) *sync.Mutex {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	if a.locks == nil {
+		a.locks = make(map[*Client]*sync.Mutex)
+	}
+	l := a.locks[c]
+	if l == nil {
+		l = new(sync.Mutex)
+		a.locks[c] = l
+	}
+	return l
+}
+
+// read computes all aggregated points of the request from the current values of the members.
+func (a *Aggregate) read(ctx cancel.Context, req Request) error {
+	var (
+		pts  Points
+		aggs []Aggregation
+		mems []map[*Client]Point
+		reqs = make(map[*Client]Points)
+	)
+	for _, p := range req.Points() {
+		if b, ok := a.bound(p); ok && b.aggregate != nil {
+			m := a.members(b.path)
+			for c, x := range m {
+				reqs[c] = append(reqs[c], x)
+			}
+			pts, aggs, mems = append(pts, p), append(aggs, b.aggregate), append(mems, m)
+		}
+	}
+	copies := make(map[Point]Point)
+	for c, xs := range reqs {
+		if err := a.fetch(ctx, c, xs, copies); err != nil {
+			return err
+		}
+	}
+	a.values.Lock()
+	defer a.values.Unlock()
+	for i, p := range pts {
+		if err := aggs[i](p, a.ordered(mems[i], copies)); err != nil {
+			return err
+		}
+	}
+	return req.Flush()
+}
+
+// write ingests the request and fans out all distributed points to the members.
+// The member points are read beforehand, so that the values are distributed in their current scale.
+// If the distribution or any member fails, the previous values of the virtual points and of the members
+// written so far are restored.
+func (a *Aggregate) write(ctx cancel.Context, req Request) error {
+	var (
+		pts   Points
+		dists []Distribution
+		mems  []map[*Client]Point
+		reqs  = make(map[*Client]Points)
+	)
+	for _, p := range req.Points() {
+		if b, ok := a.bound(p); ok && b.distribute != nil {
+			m := a.members(b.path)
+			for c, x := range m {
+				reqs[c] = append(reqs[c], x)
+			}
+			pts, dists, mems = append(pts, p), append(dists, b.distribute), append(mems, m)
+		}
+	}
+	copies := make(map[Point]Point)
+	for c, xs := range reqs {
+		if err := a.fetch(ctx, c, xs, copies); err != nil {
+			return err
+		}
+	}
+	prev := make(map[Point]Point, len(copies))
+	for p, x := range copies {
+		prev[p] = detach(x)
+	}
+	old, err := a.ingest(req, pts, dists, mems, copies)
+	if err != nil {
+		return err
+	}
+	var written []*Client
+	for _, c := range a.Members {
+		xs := reqs[c]
+		if len(xs) == 0 {
+			continue
+		}
+		// the failing member is restored as well, as its points may have been written partially
+		written = append(written, c)
+		if err := a.store(ctx, c, xs, copies); err != nil {
+			for _, c := range written {
+				a.store(ctx, c, reqs[c], prev)
+			}
+			a.values.Lock()
+			req.Points().decode(old)
+			a.values.Unlock()
+			return err
+		}
+	}
+	a.values.Lock()
+	defer a.values.Unlock()
+	return req.Flush()
+}
+
+// ingest sets the virtual points from the request, distributing the values of the points pts among the copies of the member points.
+// The previous values of the virtual points are returned, on failure they are restored.
+func (a *Aggregate) ingest(req Request, pts Points, dists []Distribution, mems []map[*Client]Point, copies map[Point]Point) ([]byte, error) {
+	a.values.Lock()
+	defer a.values.Unlock()
+	old := make([]byte, 2*req.Points().Quantity())
+	if err := req.Points().encode(old); err != nil {
+		return nil, err
+	}
+	if err := req.Ingest(); err != nil {
+		return nil, err
+	}
+	for i, p := range pts {
+		if err := dists[i](p, a.ordered(mems[i], copies)); err != nil {
+			req.Points().decode(old)
+			return nil, err
+		}
+	}
+	return old, nil
+}
+
+// fetch reads the member points together with their scale factors from the member c.
+// Detached copies of the points with their scale factors fixed to the values read are added to copies,
+// so that they can be processed without holding the member´s lock.
+func (a *Aggregate) fetch(ctx cancel.Context, c *Client, pts Points, copies map[Point]Point) error {
+	var idx []Index
+	for _, p := range pts {
+		idx = append(idx, p)
+		if sf := factorOf(p); sf != nil {
+			idx = append(idx, sf)
+		}
+	}
+	l := a.lock(c)
+	l.Lock()
+	defer l.Unlock()
+	if _, err := c.Read(ctx, idx...); err != nil {
+		return err
+	}
+	for _, p := range pts {
+		copies[p] = freeze(p)
+	}
+	return nil
+}
+
+// store transfers the values of the copies into the member points, writing them to the member c.
+func (a *Aggregate) store(ctx cancel.Context, c *Client, pts Points, copies map[Point]Point) error {
+	l := a.lock(c)
+	l.Lock()
+	defer l.Unlock()
+	idx := make([]Index, len(pts))
+	for i, p := range pts {
+		if err := transfer(p, copies[p]); err != nil {
+			return err
+		}
+		idx[i] = p
+	}
+	_, err := c.Write(ctx, idx...)
+	return err
+}
+
+// ordered returns the copies of the member points in the order of the members.
+// Members not implementing the point are kept as nil.
+func (a *Aggregate) ordered(m map[*Client]Point, copies map[Point]Point) Points {
+	pts := make(Points, len(a.Members))
+	for i, c := range a.Members {
+		if p, ok := m[c]; ok {
+			pts[i] = copies[p]
+		}
+	}
+	return pts
+}
+
+// Sum is an aggregation setting the point to the sum of all valid member values.
+func Sum(p Point, members Points) error {
+	var sum float64
+	for _, m := range members {
+		if m == nil {
+			continue
+		}
+		if v, ok := m.Float(); ok {
+			sum += v
+		}
+	}
+	return p.SetFloat(sum)
+}
+
+// Average is an aggregation setting the point to the mean of all valid member values.
+func Average(p Point, members Points) error {
+	var sum, n float64
+	for _, m := range members {
+		if m == nil {
+			continue
+		}
+		if v, ok := m.Float(); ok {
+			sum, n = sum+v, n+1
+		}
+	}
+	if n == 0 {
+		return nil
+	}
+	return p.SetFloat(sum / n)
+}
+
+// Worst returns an aggregation setting an enumerated point to the most severe state of the members.
+// The states are given in ascending order of severity, unlisted states are considered most severe, e.g.:
+//
+//	sunspec.Worst(4, 3, 2, 8, 7) // 103/St: MPPT, Sleeping, Off, Standby, Fault
+func Worst(severity ...uint32) Aggregation {
+	rank := make(map[uint32]int, len(severity))
+	for i, s := range severity {
+		rank[s] = i
+	}
+	return func(p Point, members Points) error {
+		var (
+			worst uint32
+			max   = -1
+		)
+		for _, m := range members {
+			if m == nil {
+				continue
+			}
+			v, ok := m.Float()
+			if !ok {
+				continue
+			}
+			r, ok := rank[uint32(v)]
+			if !ok {
+				r = len(severity)
+			}
+			if r > max {
+				worst, max = uint32(v), r
+			}
+		}
+		if max < 0 {
+			return nil
+		}
+		return p.SetFloat(float64(worst))
+	}
+}
+
+// Broadcast is a distribution setting all member points to the value of the point.
+func Broadcast(p Point, members Points) error {
+	v, ok := p.Float()
+	if !ok {
+		return valueless(p)
+	}
+	for _, m := range members {
+		if m == nil {
+			continue
+		}
+		if err := m.SetFloat(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Proportional returns a distribution splitting the value of the point among the members
+// in proportion to the given weights, e.g. their nominal power.
+// The weights correspond to the members in the order of Aggregate.Members, the value is split among
+// the members implementing the point in proportion to their weights. Without weights the value is split equally.
+func Proportional(weights ...float64) Distribution {
+	return func(p Point, members Points) error {
+		v, ok := p.Float()
+		if !ok {
+			return valueless(p)
+		}
+		w := make([]float64, len(members))
+		var (
+			sum float64
+			n   int
+		)
+		for i, m := range members {
+			if m == nil {
+				continue
+			}
+			if w[i] = 1; len(weights) != 0 {
+				if w[i] = 0; i < len(weights) {
+					w[i] = weights[i]
+				}
+			}
+			sum, n = sum+w[i], n+1
+		}
+		if sum == 0 && n != 0 {
+			return fmt.Errorf("%w: the weights for point %q sum up to zero", ErrIllegalValue, p.Name())
+		}
+		for i, m := range members {
+			if m == nil {
+				continue
+			}
+			if err := m.SetFloat(v * w[i] / sum); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// valueless is the error returned when distributing a point without a numeric value.
+func valueless(p Point) error {
+	return fmt.Errorf("%w: point %q has no numeric value to distribute", ErrIllegalValue, p.Name())
+}
@@ -1,7 +1,7 @@
 package sunspec
 
 import (
-	"errors"
+	"net"
 
 	"github.com/GoAethereal/cancel"
 	"github.com/GoAethereal/modbus"
@@ -23,6 +23,10 @@ func (c *Client) Scan(ctx cancel.Context, defs ...Definition) (err error) {
 }
 
 // Read requests all point values in the given address range from the server.
+// Models, groups and points are indexes themselves, hence a specific model instance
+// can be targeted using the device, e.g.:
+//
+//	c.Read(ctx, c.ModelAt(160, 1))
 func (c *Client) Read(ctx cancel.Context, idx ...Index) (Points, error) {
 	pts, err := collect(c, idx...)
 	if err != nil {
@@ -33,6 +37,7 @@ func (c *Client) Read(ctx cancel.Context, idx ...Index) (Points, error) {
 
 // Write sends all point values in the given address range to the server.
 // Read-Only points are silently skipped.
+// Like for Read a specific model instance can be targeted using ModelAt.
 func (c *Client) Write(ctx cancel.Context, idx ...Index) (Points, error) {
 	pts, err := collect(c, idx...)
 	if err != nil {
@@ -48,7 +53,7 @@ func (c *Client) Write(ctx cancel.Context, idx ...Index) (Points, error) {
 	}
 	pts = pts[:i]
 	if len(pts) == 0 {
-		return nil, errors.New("sunspec: no writable points for given index")
+		return nil, ErrReadOnly
 	}
 	return c.write(ctx, pts...)
 }
@@ -66,17 +71,31 @@ type client interface {
 var _ client = (*mbClient)(nil)
 
 type mbClient struct {
-	modbus.Client
+	requester
+	uid byte
+}
+
+func newModbusClient(o Config) *mbClient {
+	return &mbClient{requester: newRequester(o), uid: o.unit()}
 }
 
-func newModbusClient(endpoint string) *mbClient {
-	return &mbClient{
-		Client: modbus.Client{Config: modbus.Config{
-			Mode:     "tcp",
-			Kind:     "tcp",
-			Endpoint: endpoint,
-		}},
+// newRequester returns the modbus requester matching the transport of the configuration.
+func newRequester(o Config) requester {
+	network, f, err := o.transport()
+	switch {
+	case err != nil:
+		// the configuration error is reported by each request
+		return &link{dial: func(cancel.Context) (net.Conn, error) { return nil, err }}
+	case o.Security != nil:
+		return newLink(network, o.Endpoint, f, o.Security.client(o.Endpoint))
+	case network != "tcp" || f != (mbap{}):
+		return newLink(network, o.Endpoint, f, nil)
 	}
+	return &modbus.Client{Config: modbus.Config{
+		Mode:     "tcp",
+		Kind:     "tcp",
+		Endpoint: o.Endpoint,
+	}}
 }
 
 func (c *mbClient) scan(ctx cancel.Context, defs []Definition) (Device, error) {
@@ -117,22 +136,38 @@ func (c *mbClient) scan(ctx cancel.Context, defs []Definition) (Device, error) {
 	}
 }
 
+// bases are the modbus addresses at which the SunS marker may be located.
+var bases = [...]uint16{0, 40000, 50000}
+
 // marker locates the modbus stating address of the endpoint by scanning the base addresses.
 func (c *mbClient) marker(ctx cancel.Context) (uint16, error) {
-	for _, adr := range [...]uint16{0, 40000, 50000} {
-		if _, err := c.read(ctx, marker(adr).Points()...); err == nil {
+	for _, adr := range bases {
+		if c.sunspec(ctx, adr) == nil {
 			return adr, nil
 		}
 	}
-	return 0, errors.New("sunspec: could not identify the starting marker")
+	return 0, ErrMarker
+}
+
+// sunspec checks whether the SunS marker is located at the modbus address adr.
+// Readable registers holding any other value are rejected with ErrMarker.
+func (c *mbClient) sunspec(ctx cancel.Context, adr uint16) error {
+	m := marker(adr)
+	if _, err := c.read(ctx, m.Points()...); err != nil {
+		return err
+	}
+	if s, ok := m.Points()[0].(String); !ok || s.Get() != "SunS" {
+		return ErrMarker
+	}
+	return nil
 }
 
 // read attempts to request the data for all given points from the modbus endpoint.
 func (c *mbClient) read(ctx cancel.Context, pts ...Point) (Points, error) {
 	return c.execute(125, pts, func(pts Points) error {
-		res, err := c.ReadHoldingRegisters(ctx, 1, pts.address(), pts.Quantity())
+		res, err := c.ReadHoldingRegisters(ctx, c.uid, pts.address(), pts.Quantity())
 		if err != nil {
-			return err
+			return &RequestError{Index: pts.index(), Err: err}
 		}
 		return pts.decode(res)
 	})
@@ -145,7 +180,10 @@ func (c *mbClient) write(ctx cancel.Context, pts ...Point) (Points, error) {
 		if err := pts.encode(req); err != nil {
 			return err
 		}
-		return c.WriteMultipleRegisters(ctx, 1, pts.address(), req)
+		if err := c.WriteMultipleRegisters(ctx, c.uid, pts.address(), req); err != nil {
+			return &RequestError{Writing: true, Index: pts.index(), Err: err}
+		}
+		return nil
 	})
 }
 
@@ -154,7 +192,7 @@ func (c *mbClient) write(ctx cancel.Context, pts ...Point) (Points, error) {
 //
 //	ToDo: still needs handling for sync groups
 func (c *mbClient) execute(limit uint16, pts Points, cmd func(pts Points) error) (Points, error) {
-	for i, j, l := 1, 0, len(pts); j < l; j = i {
+	for i, j, l := 1, 0, len(pts); j < l; j, i = i, i+1 {
 		for _, p := range pts[i:] {
 			if ceil(pts[i-1]) != p.Address() || ceil(p)-pts[j].Address() > limit {
 				break
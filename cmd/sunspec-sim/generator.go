@@ -0,0 +1,229 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/TRICERA-energy/sunspec"
+)
+
+// duration is a time.Duration which is unmarshalled from its string representation, e.g. "1m30s".
+type duration time.Duration
+
+// UnmarshalJSON parses the duration from a json string.
+func (d *duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	v, err := time.ParseDuration(s)
+	*d = duration(v)
+	return err
+}
+
+// state is a single state of a state machine.
+type state struct {
+	Value    float64  `json:"value"`
+	Duration duration `json:"duration"`
+}
+
+// rule declares how the value of a point is generated.
+type rule struct {
+	// Path identifies the point, see sunspec.Device.Resolve.
+	Path string `json:"path"`
+	// Type selects the generator: constant, sine, ramp, noise, accumulate or states.
+	Type string `json:"type"`
+	// Value is the constant value (number or text), or the base value of the noise.
+	Value interface{} `json:"value"`
+	// Offset and Amplitude describe the sine wave, the amplitude is also used by the noise.
+	Offset    float64 `json:"offset"`
+	Amplitude float64 `json:"amplitude"`
+	// Period is the duration of one sine wave or ramp.
+	Period duration `json:"period"`
+	// From and To are the boundaries of the ramp.
+	From float64 `json:"from"`
+	To   float64 `json:"to"`
+	// Source is the path of the point integrated by the accumulator.
+	Source string `json:"source"`
+	// Factor scales the integrated value, it defaults to 1/3600 converting W into Wh.
+	Factor *float64 `json:"factor"`
+	// States are cycled through by the state machine.
+	States []state `json:"states"`
+}
+
+// generator updates the value of its point.
+type generator interface {
+	// update sets the point´s value for the elapsed time t since the start of the simulation
+	// and the time dt passed since the last update.
+	update(t, dt time.Duration) error
+}
+
+// build creates the generator declared by the rule for the device d.
+func (r rule) build(d sunspec.Device) (generator, error) {
+	p, err := point(d, r.Path)
+	if err != nil {
+		return nil, err
+	}
+	switch r.Type {
+	case "constant":
+		return &constant{p: p, v: r.Value}, nil
+	case "sine":
+		if r.Period <= 0 {
+			return nil, fmt.Errorf("%v: the sine requires a positive period", r.Path)
+		}
+		return &sine{p: p, offset: r.Offset, amplitude: r.Amplitude, period: time.Duration(r.Period)}, nil
+	case "ramp":
+		if r.Period <= 0 {
+			return nil, fmt.Errorf("%v: the ramp requires a positive period", r.Path)
+		}
+		return &ramp{p: p, from: r.From, to: r.To, period: time.Duration(r.Period)}, nil
+	case "noise":
+		v, ok := r.Value.(float64)
+		if !ok && r.Value != nil {
+			return nil, fmt.Errorf("%v: the noise requires a numeric value", r.Path)
+		}
+		return &noise{p: p, value: v, amplitude: r.Amplitude}, nil
+	case "accumulate":
+		src, err := point(d, r.Source)
+		if err != nil {
+			return nil, err
+		}
+		f := 1.0 / 3600
+		if r.Factor != nil {
+			f = *r.Factor
+		}
+		a := &accumulator{p: p, src: src, factor: f}
+		// only the counters of accumulator types roll over, others like float32 keep counting
+		_, err = sunspec.NewTracker(p)
+		a.counter = err == nil
+		// continue counting from the initial value of the point
+		a.sum, _ = p.Float()
+		return a, nil
+	case "states":
+		if len(r.States) == 0 {
+			return nil, fmt.Errorf("%v: the state machine requires at least one state", r.Path)
+		}
+		var cycle time.Duration
+		for _, s := range r.States {
+			if s.Duration <= 0 {
+				return nil, fmt.Errorf("%v: the states require a positive duration", r.Path)
+			}
+			cycle += time.Duration(s.Duration)
+		}
+		return &machine{p: p, states: r.States, cycle: cycle}, nil
+	}
+	return nil, fmt.Errorf("%v: unknown generator type %q", r.Path, r.Type)
+}
+
+// point resolves the path to a point of the device.
+func point(d sunspec.Device, path string) (sunspec.Point, error) {
+	idx, err := d.Resolve(path)
+	if err != nil {
+		return nil, err
+	}
+	p, ok := idx.(sunspec.Point)
+	if !ok {
+		return nil, fmt.Errorf("%v: the path does not identify a point", path)
+	}
+	return p, nil
+}
+
+// constant sets the point to a fixed value.
+type constant struct {
+	p sunspec.Point
+	v interface{}
+}
+
+func (g *constant) update(_, _ time.Duration) error {
+	switch v := g.v.(type) {
+	case string:
+		return g.p.SetText(v)
+	case float64:
+		return g.p.SetFloat(v)
+	}
+	return fmt.Errorf("%v: unsupported constant %v", g.p.Name(), g.v)
+}
+
+// sine sets the point to a sine wave oscillating around the offset.
+type sine struct {
+	p                 sunspec.Point
+	offset, amplitude float64
+	period            time.Duration
+}
+
+func (g *sine) update(t, _ time.Duration) error {
+	return g.p.SetFloat(g.offset + g.amplitude*math.Sin(2*math.Pi*float64(t)/float64(g.period)))
+}
+
+// ramp linearly moves the point from one value to another, starting over after each period.
+type ramp struct {
+	p        sunspec.Point
+	from, to float64
+	period   time.Duration
+}
+
+func (g *ramp) update(t, _ time.Duration) error {
+	return g.p.SetFloat(g.from + (g.to-g.from)*float64(t%g.period)/float64(g.period))
+}
+
+// noise sets the point to a random value within the amplitude around the base value.
+type noise struct {
+	p                sunspec.Point
+	value, amplitude float64
+}
+
+func (g *noise) update(_, _ time.Duration) error {
+	return g.p.SetFloat(g.value + g.amplitude*(2*rand.Float64()-1))
+}
+
+// accumulator integrates the value of the source point over time, e.g. power into energy.
+type accumulator struct {
+	p, src  sunspec.Point
+	factor  float64
+	sum     float64
+	counter bool
+}
+
+func (g *accumulator) update(_, dt time.Duration) error {
+	// unimplemented values of the source do not contribute
+	if v, ok := g.src.Float(); ok {
+		g.sum += v * dt.Seconds() * g.factor
+	}
+	if !g.counter {
+		return g.p.SetFloat(g.sum)
+	}
+	// the counter rolls over once its range is exceeded, just like the one of a real device
+	step, n := 1.0, math.Pow(2, float64(16*g.p.Quantity()))
+	if s, ok := g.p.(sunspec.Scalable); ok {
+		step = math.Pow10(int(s.Factor()))
+	}
+	if g.sum = math.Mod(g.sum, n*step); g.sum < 0 {
+		g.sum += n * step
+	}
+	// the count is rounded on setting the value, which may yield the range itself
+	if c := math.Round(g.sum / step); c < n {
+		return g.p.SetFloat(c * step)
+	}
+	return g.p.SetFloat(0)
+}
+
+// machine cycles the point through its states.
+type machine struct {
+	p      sunspec.Point
+	states []state
+	cycle  time.Duration
+}
+
+func (g *machine) update(t, _ time.Duration) error {
+	t %= g.cycle
+	for _, s := range g.states {
+		if t < time.Duration(s.Duration) {
+			return g.p.SetFloat(s.Value)
+		}
+		t -= time.Duration(s.Duration)
+	}
+	return nil
+}
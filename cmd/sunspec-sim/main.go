@@ -0,0 +1,165 @@
+// Command sunspec-sim simulates a sunspec device.
+// The models are served using the sunspec server, while their point values
+// are driven by generators declared in a json configuration:
+//
+//	{
+//		"endpoint": "localhost:502",
+//		"interval": "1s",
+//		"models": ["model_1.json", "model_103.json"],
+//		"points": [
+//			{"path": "1/Mn", "type": "constant", "value": "TRICERA energy"},
+//			{"path": "103/W", "type": "sine", "offset": 5000, "amplitude": 5000, "period": "24h"},
+//			{"path": "103/Hz", "type": "noise", "value": 50, "amplitude": 0.05},
+//			{"path": "103/TmpCab", "type": "ramp", "from": 20, "to": 45, "period": "1h"},
+//			{"path": "103/WH", "type": "accumulate", "source": "103/W"},
+//			{"path": "103/St", "type": "states", "states": [
+//				{"value": 4, "duration": "5m"},
+//				{"value": 5, "duration": "1m"},
+//				{"value": 8, "duration": "30s"}
+//			]}
+//		]
+//	}
+//
+// The models are files containing the json schema of the official sunspec model definitions,
+// relative paths are resolved against the directory of the configuration.
+// The generators are updated in the declared order every interval:
+//   - constant sets a fixed number or text, e.g. a string, the name of a state or an address
+//   - sine oscillates around the offset with the given amplitude and period
+//   - ramp linearly rises from one value to the other, starting over after each period
+//   - noise randomly deviates from the value within the amplitude
+//   - accumulate integrates the source point over time, by default converting W into Wh
+//   - states cycles through the states of enumerated points, each held for its duration
+//
+// Points not driven by a generator keep their values, including those written by clients.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"time"
+
+	"github.com/GoAethereal/cancel"
+	"github.com/TRICERA-energy/sunspec"
+)
+
+// config is the declaration of the simulated device.
+type config struct {
+	Endpoint string   `json:"endpoint"`
+	Interval duration `json:"interval"`
+	Models   []string `json:"models"`
+	Points   []rule   `json:"points"`
+}
+
+var (
+	file     = flag.String("config", "sim.json", "Path to the simulation configuration")
+	endpoint = flag.String("endpoint", "", "Overrides the endpoint of the configuration")
+)
+
+var logger = log.New(os.Stderr, "sunspec-sim: ", log.Ldate|log.Ltime)
+
+func main() {
+	flag.Parse()
+
+	cfg, err := load(*file)
+	if err != nil {
+		logger.Fatalln(err)
+	}
+	if *endpoint != "" {
+		cfg.Endpoint = *endpoint
+	}
+
+	var defs []sunspec.Definition
+	for _, m := range cfg.Models {
+		if !filepath.IsAbs(m) {
+			m = filepath.Join(filepath.Dir(*file), m)
+		}
+		b, err := ioutil.ReadFile(m)
+		if err != nil {
+			logger.Fatalln(err)
+		}
+		var def sunspec.ModelDef
+		if err := json.Unmarshal(b, &def); err != nil {
+			logger.Fatalf("%v: %v", m, err)
+		}
+		defs = append(defs, &def)
+	}
+
+	s := (sunspec.Config{Endpoint: cfg.Endpoint}).Server()
+	if err := s.Load(defs...); err != nil {
+		logger.Fatalln(err)
+	}
+
+	var gens []generator
+	for _, r := range cfg.Points {
+		g, err := r.build(s)
+		if err != nil {
+			logger.Fatalln(err)
+		}
+		gens = append(gens, g)
+	}
+
+	ctx := cancel.New()
+	go func() {
+		sig := make(chan os.Signal, 1)
+		signal.Notify(sig, os.Interrupt)
+		<-sig
+		ctx.Cancel()
+	}()
+
+	st := new(sunspec.Store)
+	go simulate(ctx, st, cfg.Interval, gens)
+
+	logger.Printf("serving %v models on %v", len(defs), cfg.Endpoint)
+	if err := s.Serve(ctx, st.Handle); err != nil {
+		logger.Fatalln(err)
+	}
+}
+
+// load reads the configuration from the file.
+func load(file string) (*config, error) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &config{Endpoint: "localhost:502", Interval: duration(time.Second)}
+	if err := json.Unmarshal(b, cfg); err != nil {
+		return nil, err
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = duration(time.Second)
+	}
+	return cfg, nil
+}
+
+// simulate updates all generators every interval until the context is canceled.
+func simulate(ctx cancel.Context, st *sunspec.Store, interval duration, gens []generator) {
+	start := time.Now()
+	last := start
+	t := time.NewTicker(time.Duration(interval))
+	defer t.Stop()
+	for {
+		now := time.Now()
+		if err := st.Update(func() error {
+			// a failing generator must not keep the remaining ones from being updated
+			for _, g := range gens {
+				if err := g.update(now.Sub(start), now.Sub(last)); err != nil {
+					logger.Println(err)
+				}
+			}
+			return nil
+		}); err != nil {
+			logger.Println(err)
+		}
+		last = now
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
@@ -0,0 +1,276 @@
+// Command sunspec inspects sunspec devices.
+// It connects to the device, scans it using the built-in and user-supplied model definitions
+// and prints the models, groups and points with their scaled values, units and enumerated states.
+//
+// Usage:
+//
+//	sunspec [flags] <command> [arguments]
+//
+// The commands are:
+//
+//	scan                  print the tree of all models of the device
+//	read <path>...        print the models, groups or points identified by the paths
+//	write <path> <value>  set the point identified by the path to the value
+//	watch <path>...       continuously print the models, groups or points identified by the paths
+//	discover <network>    sweep the network given in CIDR notation and print the devices found
+//
+// Enumerated points are written using either their value or the name of a state,
+// bitfields accept a comma separated list of state names, e.g. "GROUND_FAULT,OVER_TEMP".
+//
+// Paths identify the elements of the device, e.g. "1/Mn", "103/W" or "705/crv[2]/pt[5]/V".
+// Additional model definitions are loaded from the json files in the directory given by -models.
+// The discover command probes the hosts on the port and unit identifiers given by -port and -units,
+// e.g. "sunspec -units 1-3,126 discover 192.168.0.0/24".
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GoAethereal/cancel"
+	"github.com/TRICERA-energy/sunspec"
+	"github.com/TRICERA-energy/sunspec/models"
+)
+
+var (
+	endpoint = flag.String("endpoint", "localhost:502", "Endpoint of the device as host:port")
+	mode     = flag.String("mode", "tcp", "Framing of the communication: tcp or rtu")
+	kind     = flag.String("kind", "tcp", "Network of the communication: tcp or udp")
+	dir      = flag.String("models", "", "Directory containing additional model definitions in json")
+	timeout  = flag.Duration("timeout", 5*time.Second, "Timeout of each request")
+	interval = flag.Duration("interval", time.Second, "Interval between the reads of the watch command")
+	unit     = flag.Uint("unit", 1, "Unit identifier of the device")
+	port     = flag.Int("port", 502, "Port of the hosts probed by the discover command")
+	units    = flag.String("units", "1", "Unit identifiers probed by the discover command, e.g. 1-3,126")
+	workers  = flag.Int("concurrency", 16, "Number of hosts probed simultaneously by the discover command")
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: sunspec [flags] scan|read|write|watch|discover [arguments]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if err := run(flag.Args()); err != nil {
+		fmt.Fprintln(os.Stderr, "sunspec:", strings.TrimPrefix(err.Error(), "sunspec: "))
+		os.Exit(1)
+	}
+}
+
+// run executes the command given by the arguments.
+func run(args []string) error {
+	if len(args) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if args[0] == "discover" {
+		if len(args) != 2 {
+			return fmt.Errorf("discover requires a network")
+		}
+		return discover(args[1])
+	}
+	if *unit == 0 || *unit > 247 {
+		return fmt.Errorf("invalid unit identifier %v", *unit)
+	}
+	defs, err := definitions(*dir)
+	if err != nil {
+		return err
+	}
+	c := (sunspec.Config{Endpoint: *endpoint, Mode: *mode, Kind: *kind, Unit: byte(*unit)}).Client()
+	defer c.Disconnect()
+	if err := c.Scan(cancel.New().Timeout(*timeout), defs...); err != nil {
+		return err
+	}
+	switch cmd, args := args[0], args[1:]; cmd {
+	case "scan":
+		return scan(c)
+	case "read":
+		return read(c, args)
+	case "write":
+		if len(args) != 2 {
+			return fmt.Errorf("write requires a path and a value")
+		}
+		return write(c, args[0], args[1])
+	case "watch":
+		return watch(c, args)
+	default:
+		return fmt.Errorf("unknown command %q", cmd)
+	}
+}
+
+// definitions returns the built-in definitions, extended and overridden by the ones found in dir.
+func definitions(dir string) ([]sunspec.Definition, error) {
+	builtin, err := models.Definitions()
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uint16]sunspec.Definition)
+	for _, def := range builtin {
+		byID[def.ID()] = def
+	}
+	if dir != "" {
+		files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range files {
+			b, err := ioutil.ReadFile(f)
+			if err != nil {
+				return nil, err
+			}
+			def := &sunspec.ModelDef{}
+			if err := json.Unmarshal(b, def); err != nil {
+				return nil, fmt.Errorf("%v: %w", f, err)
+			}
+			byID[def.ID()] = def
+		}
+	}
+	defs := make([]sunspec.Definition, 0, len(byID))
+	for _, def := range byID {
+		defs = append(defs, def)
+	}
+	return defs, nil
+}
+
+// scan prints all models of the device.
+func scan(c *sunspec.Client) error {
+	for _, m := range c.Models() {
+		if _, err := c.Read(cancel.New().Timeout(*timeout), m); err != nil {
+			return err
+		}
+		tree(os.Stdout, m, 0)
+	}
+	return nil
+}
+
+// resolve returns the elements of the device identified by the paths.
+func resolve(c *sunspec.Client, paths []string) ([]sunspec.Index, error) {
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("at least one path is required")
+	}
+	idx := make([]sunspec.Index, len(paths))
+	for i, p := range paths {
+		var err error
+		if idx[i], err = c.Resolve(p); err != nil {
+			return nil, err
+		}
+	}
+	return idx, nil
+}
+
+// read prints the elements identified by the paths.
+func read(c *sunspec.Client, paths []string) error {
+	idx, err := resolve(c, paths)
+	if err != nil {
+		return err
+	}
+	if _, err := c.Read(cancel.New().Timeout(*timeout), idx...); err != nil {
+		return err
+	}
+	for i, x := range idx {
+		if p, ok := x.(sunspec.Point); ok {
+			fmt.Printf("%v = %v\n", paths[i], format(p))
+			continue
+		}
+		tree(os.Stdout, x, 0)
+	}
+	return nil
+}
+
+// write sets the point identified by the path to the value and prints the result.
+func write(c *sunspec.Client, path, value string) error {
+	idx, err := c.Resolve(path)
+	if err != nil {
+		return err
+	}
+	p, ok := idx.(sunspec.Point)
+	if !ok {
+		return fmt.Errorf("path %q does not identify a point", path)
+	}
+	ctx := cancel.New().Timeout(*timeout)
+	// the model is read beforehand, as its scale factors are required for parsing the value
+	m := p.Origin()
+	for m.Origin() != nil {
+		m = m.Origin()
+	}
+	if _, err := c.Read(ctx, m); err != nil {
+		return err
+	}
+	if err := p.SetText(value); err != nil {
+		return err
+	}
+	if _, err := c.Write(ctx, p); err != nil {
+		return err
+	}
+	if _, err := c.Read(ctx, p); err != nil {
+		return err
+	}
+	fmt.Printf("%v = %v\n", path, format(p))
+	return nil
+}
+
+// watch continuously prints the elements identified by the paths until interrupted.
+func watch(c *sunspec.Client, paths []string) error {
+	for {
+		fmt.Println(time.Now().Format(time.RFC3339))
+		if err := read(c, paths); err != nil {
+			return err
+		}
+		time.Sleep(*interval)
+	}
+}
+
+// discover sweeps the network and prints the devices found.
+func discover(network string) error {
+	ids, err := parseUnits(*units)
+	if err != nil {
+		return err
+	}
+	d := sunspec.Discovery{
+		Network:     network,
+		Port:        *port,
+		Units:       ids,
+		Timeout:     *timeout,
+		Concurrency: *workers,
+		Config:      sunspec.Config{Mode: *mode, Kind: *kind},
+	}
+	devs, err := d.Discover(cancel.New())
+	if err != nil {
+		return err
+	}
+	for _, dev := range devs {
+		fmt.Printf("%v unit %v base %v: %v %v (serial %v, version %v)\n",
+			dev.Endpoint, dev.Unit, dev.Base, dev.Manufacturer, dev.Model, dev.Serial, dev.Version)
+	}
+	return nil
+}
+
+// parseUnits parses a comma separated list of unit identifiers and ranges, e.g. "1-3,126".
+func parseUnits(s string) ([]byte, error) {
+	var ids []byte
+	for _, f := range strings.Split(s, ",") {
+		lo, hi := f, f
+		if i := strings.Index(f, "-"); i >= 0 {
+			lo, hi = f[:i], f[i+1:]
+		}
+		a, err := strconv.ParseUint(strings.TrimSpace(lo), 10, 8)
+		if err != nil {
+			return nil, fmt.Errorf("invalid unit identifier %q", f)
+		}
+		b, err := strconv.ParseUint(strings.TrimSpace(hi), 10, 8)
+		if err != nil || b < a {
+			return nil, fmt.Errorf("invalid unit identifier %q", f)
+		}
+		for u := a; u <= b; u++ {
+			ids = append(ids, byte(u))
+		}
+	}
+	return ids, nil
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/TRICERA-energy/sunspec"
+)
+
+// tree prints the model, group or point x and all of its elements indented by depth.
+func tree(w io.Writer, x sunspec.Index, depth int) {
+	indent := strings.Repeat("  ", depth)
+	switch x := x.(type) {
+	case sunspec.Point:
+		fmt.Fprintf(w, "%v%-12v = %v\n", indent, x.Name(), format(x))
+	case sunspec.Model:
+		fmt.Fprintf(w, "%v%v %v (address %v, length %v)\n", indent, x.ID().Get(), x.Name(), x.Address(), x.Length().Get())
+		children(w, x, depth+1)
+	case sunspec.Group:
+		fmt.Fprintf(w, "%v%v\n", indent, x.Name())
+		children(w, x, depth+1)
+	}
+}
+
+// children prints the points and sub-groups of the group g.
+func children(w io.Writer, g sunspec.Group, depth int) {
+	for _, p := range g.Points() {
+		tree(w, p, depth)
+	}
+	for _, sub := range g.Groups() {
+		tree(w, sub, depth)
+	}
+}
+
+// format returns the human readable value of the point, including its unit or enumerated state.
+func format(p sunspec.Point) string {
+	if !p.Valid() {
+		return "n/a"
+	}
+	switch p := p.(type) {
+	case sunspec.Enum16:
+		return fmt.Sprintf("%v (%v)", p.Get(), p.State())
+	case sunspec.Enum32:
+		return fmt.Sprintf("%v (%v)", p.Get(), p.State())
+	case sunspec.Bitfield16:
+		return fmt.Sprintf("0x%04X [%v]", p.Get(), strings.Join(p.States(), " "))
+	case sunspec.Bitfield32:
+		return fmt.Sprintf("0x%08X [%v]", p.Get(), strings.Join(p.States(), " "))
+	case sunspec.Bitfield64:
+		return fmt.Sprintf("0x%016X [%v]", p.Get(), strings.Join(p.States(), " "))
+	case sunspec.String:
+		return strconv.Quote(p.Text())
+	}
+	if u := p.Units(); u != "" {
+		return p.Text() + " " + u
+	}
+	return p.Text()
+}
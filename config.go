@@ -1,19 +1,68 @@
 package sunspec
 
+import (
+	"errors"
+	"time"
+)
+
 // Config is the configuration for a client or server.
 type Config struct {
 	// Endpoint specifics the sunspec host and is mandatory.
-	// Currently only modbus tcp-networking is supported.
 	// The schema must be host:port
 	Endpoint string
+	// Mode defines the communication framing, valid modes are:
+	//	- tcp (default)
+	//	- rtu, commonly used by serial-to-ethernet converters as rtu-over-tcp
+	Mode string
+	// Kind specifies the underlying network layer, valid kinds are:
+	//	- tcp (default)
+	//	- udp
+	Kind string
+	// Security enables Modbus/TCP Security for the client or server if not nil,
+	// securing the connections using mutual TLS authentication.
+	Security *Security
+	// MaxConnections limits the number of simultaneous client connections of a server.
+	// Further connections are refused, zero means unlimited.
+	MaxConnections int
+	// IdleTimeout closes client connections of a server,
+	// which did not send a request for the given duration. Zero disables the timeout.
+	IdleTimeout time.Duration
+	// Unit is the modbus unit identifier addressed by a client,
+	// e.g. a device behind a gateway. Zero defaults to 1.
+	Unit byte
+}
+
+// unit returns the unit identifier of the configuration.
+func (o Config) unit() byte {
+	if o.Unit == 0 {
+		return 1
+	}
+	return o.Unit
+}
+
+// transport returns the network and framer of the configuration.
+func (o Config) transport() (network string, f framer, err error) {
+	switch o.Kind {
+	case "", "tcp":
+		network = "tcp"
+	case "udp":
+		if o.Security != nil {
+			return "", nil, errors.New("sunspec: security is not supported for udp")
+		}
+		network = "udp"
+	default:
+		return "", nil, errors.New("sunspec: unsupported kind " + o.Kind)
+	}
+	f, err = newFramer(o.Mode)
+	return network, f, err
 }
 
 // Client instantiates a new client from the given configuration.
 func (o Config) Client() *Client {
-	return &Client{client: newModbusClient(o.Endpoint)}
+	return &Client{client: newModbusClient(o)}
 }
 
 // Server instantiates a new server from the given configuration.
 func (o Config) Server() *Server {
-	return &Server{server: newModbusServer(o.Endpoint)}
+	return &Server{server: newModbusServer(o)}
 }
@@ -1,5 +1,10 @@
 package sunspec
 
+import (
+	"fmt"
+	"net"
+)
+
 func toInt16(v interface{}) int16 {
 	switch v := v.(type) {
 	case int:
@@ -103,3 +108,58 @@ func toByteS(v interface{}) []byte {
 	}
 	return nil
 }
+
+// toIpaddr parses the textual representation of an IPv4 address, e.g. "192.168.1.10".
+// Without a value the address is not implemented, invalid values result in an error.
+func toIpaddr(v interface{}) (r [4]byte, err error) {
+	if v == nil {
+		return r, nil
+	}
+	s, _ := v.(string)
+	ip := net.ParseIP(s).To4()
+	if ip == nil {
+		return r, fmt.Errorf("%v is not an IPv4 address", v)
+	}
+	copy(r[:], ip)
+	return r, nil
+}
+
+// toIpv6addr parses the textual representation of an IPv6 address, e.g. "fe80::1".
+// Without a value the address is not implemented, invalid values result in an error.
+func toIpv6addr(v interface{}) (r [16]byte, err error) {
+	if v == nil {
+		return r, nil
+	}
+	s, _ := v.(string)
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return r, fmt.Errorf("%v is not an IPv6 address", v)
+	}
+	copy(r[:], ip.To16())
+	return r, nil
+}
+
+// toEui48 parses the textual representation of a MAC address, e.g. "00:11:22:33:44:55".
+// The 6 bytes of the address occupy the lower part of the 8 byte container.
+// Without a valid value the address is not implemented, i.e. all bytes are 0xFF.
+func toEui48(v interface{}) (r [8]byte, err error) {
+	r = [8]byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}
+	if v == nil {
+		return r, nil
+	}
+	s, _ := v.(string)
+	mac, err := net.ParseMAC(s)
+	if err != nil || len(mac) != 6 {
+		return r, fmt.Errorf("%v is not an EUI-48 address", v)
+	}
+	r = [8]byte{}
+	copy(r[2:], mac)
+	return r, nil
+}
+
+// padded returns a NUL padded buffer of n bytes holding the prefix of v fitting into it.
+func padded(v []byte, n int) []byte {
+	b := make([]byte, n)
+	copy(b, v)
+	return b
+}
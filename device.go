@@ -1,14 +1,18 @@
 package sunspec
 
-import "errors"
-
 // Device describes a sunspec compliant device.
 type Device interface {
 	// Model returns the first immediate model identified by id.
 	Model(id uint16) Model
-	// Models returns all models from the device.
+	// ModelAt returns the n-th (starting at 0) instance of the model identified by id.
+	// Devices may legitimately contain several instances of the same model, e.g. for multiple MPPTs.
+	ModelAt(id uint16, n int) Model
+	// Models returns all models from the device, ordered by their modbus address.
 	// If ids are omitted all models are returned.
 	Models(ids ...uint16) Models
+	// Resolve retrieves the model, group or point referenced by path, e.g. "103/W" or "705/crv[2]/pt[5]/V".
+	// The returned Index can be asserted to Model, Group or Point respectively.
+	Resolve(path string) (Index, error)
 }
 
 // collect retrieves all the distinct points in a given address range.
@@ -26,12 +30,12 @@ func collect(d Device, idx ...Index) (Points, error) {
 				case idx.Address() <= g.Address() && ceil(idx) >= ceil(g.Points().index()):
 					pts = append(pts, g.Points()...)
 				case g.Atomic():
-					return errors.New("sunspec: the operation can not be done for an atomic group")
+					return ErrAtomicViolation
 				default:
 					for _, p := range g.Points() {
 						if intersect(idx, p) {
 							if idx.Address() > p.Address() || ceil(idx) < ceil(p) {
-								return errors.New("sunspec: point not fully contained by index")
+								return ErrPartialPoint
 							}
 							pts = append(pts, p)
 						}
@@ -44,7 +48,7 @@ func collect(d Device, idx ...Index) (Points, error) {
 		}
 	}
 	if len(pts) == 0 {
-		return nil, errors.New("sunspec: index does not reference any points in the device")
+		return nil, ErrUnknownAddress
 	}
 	return pts, nil
 }
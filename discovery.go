@@ -0,0 +1,229 @@
+package sunspec
+
+import (
+	"errors"
+	"net"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/GoAethereal/cancel"
+)
+
+// Discovery sweeps a network range for sunspec devices.
+// Every host is probed for the SunS marker at the base addresses 0, 40000 and 50000
+// on each unit identifier, followed by reading the common model of the device.
+type Discovery struct {
+	// Network is the range to sweep in CIDR notation, e.g. "192.168.0.0/24".
+	// A single address is accepted as well.
+	Network string
+	// Port is the modbus port of the hosts, zero defaults to 502.
+	Port int
+	// Units are the unit identifiers probed on each host, by default only 1.
+	Units []byte
+	// Timeout bounds each probe of a host and unit, zero defaults to one second.
+	Timeout time.Duration
+	// Concurrency limits the number of hosts probed simultaneously, zero defaults to 16.
+	Concurrency int
+	// Config is the template of the clients, its Endpoint and Unit are set by the discovery.
+	Config Config
+}
+
+// Discovered describes a sunspec device found by a discovery.
+type Discovered struct {
+	// Endpoint is the host:port of the device.
+	Endpoint string
+	// Unit is the modbus unit identifier of the device.
+	Unit byte
+	// Base is the modbus address of the SunS marker.
+	Base uint16
+	// DeviceInfo is the identity read from the common model.
+	DeviceInfo
+}
+
+// Discover sweeps the network returning all devices found, ordered by their address and unit.
+// Hosts not accepting the connection within the timeout are skipped with all of their units.
+// Canceling the context stops the sweep, returning the devices found so far.
+func (d Discovery) Discover(ctx cancel.Context) ([]Discovered, error) {
+	hosts, err := d.hosts()
+	if err != nil {
+		return nil, err
+	}
+	port := d.Port
+	if port == 0 {
+		port = 502
+	}
+	units := d.Units
+	if len(units) == 0 {
+		units = []byte{1}
+	}
+	timeout := d.Timeout
+	if timeout <= 0 {
+		timeout = time.Second
+	}
+	workers := d.Concurrency
+	if workers <= 0 {
+		workers = 16
+	}
+	if workers > len(hosts) {
+		workers = len(hosts)
+	}
+
+	type result struct {
+		host int
+		dev  Discovered
+	}
+	var (
+		mtx   sync.Mutex
+		wg    sync.WaitGroup
+		res   []result
+		queue = make(chan int)
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for h := range queue {
+				cfg := d.Config
+				cfg.Endpoint = net.JoinHostPort(hosts[h].String(), strconv.Itoa(port))
+				for _, dev := range probe(ctx, cfg, units, timeout) {
+					mtx.Lock()
+					res = append(res, result{host: h, dev: dev})
+					mtx.Unlock()
+				}
+			}
+		}()
+	}
+sweep:
+	for h := range hosts {
+		select {
+		case <-ctx.Done():
+			break sweep
+		case queue <- h:
+		}
+	}
+	close(queue)
+	wg.Wait()
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].host != res[j].host {
+			return res[i].host < res[j].host
+		}
+		return res[i].dev.Unit < res[j].dev.Unit
+	})
+	devs := make([]Discovered, len(res))
+	for i, r := range res {
+		devs[i] = r.dev
+	}
+	return devs, nil
+}
+
+// hosts returns all host addresses of the network.
+// For IPv4 the network and broadcast addresses are omitted.
+func (d Discovery) hosts() ([]net.IP, error) {
+	if ip := net.ParseIP(d.Network); ip != nil {
+		return []net.IP{ip}, nil
+	}
+	ip, n, err := net.ParseCIDR(d.Network)
+	if err != nil {
+		return nil, errors.New("sunspec: invalid network " + d.Network)
+	}
+	ones, bits := n.Mask.Size()
+	if bits-ones > 16 {
+		return nil, errors.New("sunspec: network " + d.Network + " exceeds the maximum number of hosts")
+	}
+	if v4 := ip.To4(); v4 != nil {
+		ip = v4
+	}
+	var hosts []net.IP
+	for ip := ip.Mask(n.Mask); n.Contains(ip); ip = next(ip) {
+		hosts = append(hosts, ip)
+	}
+	if len(ip) == net.IPv4len && bits-ones > 1 {
+		hosts = hosts[1 : len(hosts)-1]
+	}
+	return hosts, nil
+}
+
+// next returns the address following ip, wrapping around to zero on overflow.
+func next(ip net.IP) net.IP {
+	n := append(net.IP(nil), ip...)
+	for i := len(n) - 1; i >= 0; i-- {
+		if n[i]++; n[i] != 0 {
+			break
+		}
+	}
+	return n
+}
+
+// probe identifies the devices on the units of the configured endpoint.
+// All units share a single connection to the host.
+func probe(ctx cancel.Context, cfg Config, units []byte, timeout time.Duration) (devs []Discovered) {
+	r := newRequester(cfg)
+	defer r.Disconnect()
+	for _, u := range units {
+		c := &mbClient{requester: r, uid: u}
+		sig := cancel.New().Propagate(ctx).Timeout(timeout)
+		dev, err := c.identify(sig)
+		sig.Cancel()
+		switch {
+		case err == nil:
+			dev.Endpoint = cfg.Endpoint
+			devs = append(devs, dev)
+		case unreachable(err):
+			// the host is unreachable, hence the remaining units are skipped.
+			// Units not responding are probed nevertheless, as is common behind serial gateways.
+			return devs
+		}
+		select {
+		case <-ctx.Done():
+			return devs
+		default:
+		}
+	}
+	return devs
+}
+
+// identify reads the common model of the device following the SunS marker.
+// Base addresses not holding the marker or not followed by the common model are skipped.
+func (c *mbClient) identify(ctx cancel.Context) (Discovered, error) {
+	err := ErrMarker
+	for _, adr := range bases {
+		select {
+		case <-ctx.Done():
+			// the remaining base addresses would fail without attempting a request
+			return Discovered{}, err
+		default:
+		}
+		switch err = c.sunspec(ctx, adr); {
+		case unreachable(err):
+			return Discovered{}, err
+		case err != nil:
+			continue
+		}
+		var info DeviceInfo
+		if info, err = c.common(ctx, adr); err == nil {
+			return Discovered{Unit: c.uid, Base: adr, DeviceInfo: info}, nil
+		}
+	}
+	return Discovered{}, err
+}
+
+// unreachable reports whether the error was caused by failing to connect to the host.
+func unreachable(err error) bool {
+	var op *net.OpError
+	return errors.As(err, &op) && op.Op == "dial"
+}
+
+// common reads the common model following the SunS marker at the modbus address adr.
+func (c *mbClient) common(ctx cancel.Context, adr uint16) (DeviceInfo, error) {
+	m := common(adr + 2)
+	if _, err := c.read(ctx, m.Points()...); err != nil {
+		return DeviceInfo{}, err
+	}
+	if m.ID().Get() != 1 {
+		return DeviceInfo{}, errors.New("sunspec: the first model is not the common model")
+	}
+	return Info(Models{m})
+}
@@ -0,0 +1,78 @@
+package sunspec
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/GoAethereal/modbus"
+)
+
+var (
+	// ErrUnknownAddress indicates that an index does not reference any points in the device.
+	ErrUnknownAddress = errors.New("sunspec: index does not reference any points in the device")
+	// ErrPartialPoint indicates that an index only covers some of the registers of a point.
+	ErrPartialPoint = errors.New("sunspec: point not fully contained by index")
+	// ErrAtomicViolation indicates that an index only covers parts of an atomic group.
+	ErrAtomicViolation = errors.New("sunspec: the operation can not be done for an atomic group")
+	// ErrReadOnly indicates an attempt to write points which are not writable.
+	ErrReadOnly = errors.New("sunspec: no writable points for given index")
+	// ErrVerification indicates that a model is violating the sunspec specification.
+	// The returned errors are wrapping it, describing the actual violation.
+	ErrVerification = errors.New("sunspec: model verification failed")
+	// ErrMarker indicates that the starting marker "SunS" could not be found at any of the base addresses.
+	ErrMarker = errors.New("sunspec: could not identify the starting marker")
+	// ErrOutOfRange indicates that a value exceeds the boundaries of its point.
+	ErrOutOfRange = errors.New("sunspec: value out of boundary")
+	// ErrIllegalValue indicates that a value is not permitted by the point´s definition,
+	// e.g. an undefined enumerated symbol or a set-point exceeding its declared minimum/maximum.
+	ErrIllegalValue = errors.New("sunspec: value not permitted by the point definition")
+	// ErrAccessDenied indicates that a request was refused by the server´s access policy.
+	ErrAccessDenied = errors.New("sunspec: access denied")
+)
+
+// RequestError describes a failed transaction between a client and the server.
+// If the server responded with a modbus exception it is wrapped by the error
+// and can be retrieved using errors.As:
+//
+//	var ex modbus.Exception
+//	if errors.As(err, &ex) && ex == modbus.IllegalDataAddress {
+//		// handle the exception
+//	}
+type RequestError struct {
+	// Writing specifies whether the transaction was attempting to set point values.
+	Writing bool
+	// Index is the modbus address range affected by the transaction.
+	Index Index
+	// Err is the underlying cause of the failure.
+	Err error
+}
+
+// Error formats the error as string.
+func (e *RequestError) Error() string {
+	op := "read"
+	if e.Writing {
+		op = "write"
+	}
+	return fmt.Sprintf("sunspec: %v of %v registers at address %v failed: %v", op, e.Index.Quantity(), e.Index.Address(), e.Err)
+}
+
+// Unwrap returns the underlying cause of the failure.
+func (e *RequestError) Unwrap() error { return e.Err }
+
+// exception translates an error into the modbus exception returned by the server.
+// Errors wrapping a modbus exception are passed on as is, access, addressing and value errors of the package
+// are mapped to their respective exception and all other errors are treated as device failure.
+func exception(err error) modbus.Exception {
+	var ex modbus.Exception
+	switch {
+	case errors.As(err, &ex):
+		return ex
+	case errors.Is(err, ErrAccessDenied):
+		return modbus.IllegalFunction
+	case errors.Is(err, ErrUnknownAddress), errors.Is(err, ErrPartialPoint), errors.Is(err, ErrAtomicViolation):
+		return modbus.IllegalDataAddress
+	case errors.Is(err, ErrIllegalValue), errors.Is(err, ErrOutOfRange):
+		return modbus.IllegalDataValue
+	}
+	return modbus.SlaveDeviceFailure
+}
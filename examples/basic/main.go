@@ -72,11 +72,24 @@ func handler(_ cancel.Context, req sunspec.Request) error {
 	return nil
 }
 
+// logging is a middleware printing every incoming write request.
+func logging(next sunspec.Handler) sunspec.Handler {
+	return func(ctx cancel.Context, req sunspec.Request) error {
+		if req.Writing() {
+			logger.Printf("write request for %v points starting at %v", len(req.Points()), req.Points().First().Address())
+		}
+		return next(ctx, req)
+	}
+}
+
 // Server starts up a new sunspec server.
 func Server() {
 	// create a new sunspec server instance
 	s := (sunspec.Config{Endpoint: endpoint}).Server()
 
+	// wrap the handler with the logging middleware
+	s.Use(logging)
+
 	// start serving
 	logger.Println(s.Serve(ctx, handler, defs...))
 }
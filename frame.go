@@ -0,0 +1,145 @@
+package sunspec
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// maxADU is the maximum length of a modbus rtu application data unit.
+const maxADU = 256
+
+// errFrame indicates a received frame which is to be skipped, e.g. due to a checksum mismatch.
+var errFrame = errors.New("sunspec: invalid frame")
+
+// framer encodes and decodes modbus application data units.
+type framer interface {
+	// encode builds the adu for the pdu.
+	encode(tid uint16, uid byte, pdu []byte) []byte
+	// decode reads the next adu returning its transaction id, unit id and pdu.
+	// Depending on the framing the length of the adu is derived from the pdu,
+	// which differs for requests and responses.
+	// The error errFrame is returned for frames that were read but must be skipped.
+	decode(r *bufio.Reader, request bool) (tid uint16, uid byte, pdu []byte, err error)
+	// transactional reports whether the framing carries the transaction id.
+	transactional() bool
+}
+
+// newFramer returns the framer for the mode.
+func newFramer(mode string) (framer, error) {
+	switch mode {
+	case "", "tcp":
+		return mbap{}, nil
+	case "rtu":
+		return rtu{}, nil
+	}
+	return nil, errors.New("sunspec: unsupported mode " + mode)
+}
+
+// mbap is the framing of modbus tcp, prefixing the pdu with the modbus application protocol header.
+type mbap struct{}
+
+func (mbap) encode(tid uint16, uid byte, pdu []byte) []byte {
+	adu := make([]byte, 7, 7+len(pdu))
+	binary.BigEndian.PutUint16(adu[0:], tid)
+	binary.BigEndian.PutUint16(adu[4:], uint16(1+len(pdu)))
+	adu[6] = uid
+	return append(adu, pdu...)
+}
+
+func (mbap) decode(r *bufio.Reader, _ bool) (tid uint16, uid byte, pdu []byte, err error) {
+	// mbap header: transaction id, protocol id, length, unit id
+	hdr := make([]byte, 7)
+	if _, err := io.ReadFull(r, hdr); err != nil {
+		return 0, 0, nil, err
+	}
+	l := binary.BigEndian.Uint16(hdr[4:])
+	if l < 2 || l > 254 {
+		return 0, 0, nil, errors.New("sunspec: received malformed frame")
+	}
+	pdu = make([]byte, l-1)
+	if _, err := io.ReadFull(r, pdu); err != nil {
+		return 0, 0, nil, err
+	}
+	if binary.BigEndian.Uint16(hdr[2:]) != 0 {
+		// not a modbus frame
+		return 0, 0, nil, errFrame
+	}
+	return binary.BigEndian.Uint16(hdr[0:]), hdr[6], pdu, nil
+}
+
+func (mbap) transactional() bool { return true }
+
+// rtu is the framing of modbus rtu, consisting of the unit id, the pdu and a trailing crc.
+// Used on top of networks it is commonly known as rtu-over-tcp.
+type rtu struct{}
+
+func (rtu) encode(_ uint16, uid byte, pdu []byte) []byte {
+	adu := make([]byte, 1, 3+len(pdu))
+	adu[0] = uid
+	adu = append(adu, pdu...)
+	crc := crc16(adu)
+	return append(adu, byte(crc), byte(crc>>8))
+}
+
+func (rtu) decode(r *bufio.Reader, request bool) (tid uint16, uid byte, pdu []byte, err error) {
+	adu := make([]byte, 2, maxADU)
+	if _, err := io.ReadFull(r, adu); err != nil {
+		return 0, 0, nil, err
+	}
+	// the length is determined by the function code and, if present, the byte count
+	var n, bc int
+	switch code := adu[1]; {
+	case code >= 0x80:
+		n = 5
+	case !request && (code <= 0x04 || code == 0x17):
+		n, bc = 5, 2
+	case code <= 0x06 || (!request && (code == 0x0F || code == 0x10)):
+		n = 8
+	case request && (code == 0x0F || code == 0x10):
+		n, bc = 9, 6
+	case request && code == 0x17:
+		n, bc = 13, 10
+	default:
+		return 0, 0, nil, errors.New("sunspec: received frame with unsupported function code")
+	}
+	if bc > 0 {
+		if adu = adu[:bc+1]; len(adu) > 2 {
+			if _, err := io.ReadFull(r, adu[2:]); err != nil {
+				return 0, 0, nil, err
+			}
+		}
+		n += int(adu[bc])
+	}
+	if n > maxADU {
+		return 0, 0, nil, errors.New("sunspec: received frame exceeding the maximum length")
+	}
+	l := len(adu)
+	adu = adu[:n]
+	if _, err := io.ReadFull(r, adu[l:]); err != nil {
+		return 0, 0, nil, err
+	}
+	if crc := crc16(adu[:n-2]); adu[n-2] != byte(crc) || adu[n-1] != byte(crc>>8) {
+		return 0, 0, nil, errFrame
+	}
+	return 0, adu[0], adu[1 : n-2], nil
+}
+
+func (rtu) transactional() bool { return false }
+
+// crc16 calculates the modbus rtu checksum.
+func crc16(data []byte) uint16 {
+	crc := uint16(0xFFFF)
+	for _, b := range data {
+		crc ^= uint16(b)
+		for i := 0; i < 8; i++ {
+			if crc&1 != 0 {
+				crc = crc>>1 ^ 0xA001
+			} else {
+				crc >>= 1
+			}
+		}
+	}
+	return crc
+}
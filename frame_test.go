@@ -0,0 +1,93 @@
+package sunspec
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestRtuDecode(t *testing.T) {
+	frame := func(pdu ...byte) []byte { return rtu{}.encode(0, 1, pdu) }
+	corrupt := func(adu []byte) []byte {
+		adu[len(adu)-1] ^= 0xFF
+		return adu
+	}
+	tests := []struct {
+		name    string
+		adu     []byte
+		request bool
+		pdu     []byte
+		fail    bool
+		err     error
+	}{
+		{"read request", frame(0x03, 0, 0, 0, 2), true, []byte{0x03, 0, 0, 0, 2}, false, nil},
+		{"write single request", frame(0x06, 0, 1, 0, 7), true, []byte{0x06, 0, 1, 0, 7}, false, nil},
+		{"write multiple request", frame(0x10, 0, 0, 0, 1, 2, 0, 7), true, []byte{0x10, 0, 0, 0, 1, 2, 0, 7}, false, nil},
+		{"read write request", frame(0x17, 0, 0, 0, 1, 0, 2, 0, 1, 2, 0, 7), true, []byte{0x17, 0, 0, 0, 1, 0, 2, 0, 1, 2, 0, 7}, false, nil},
+		{"read response", frame(0x03, 4, 0, 1, 0, 2), false, []byte{0x03, 4, 0, 1, 0, 2}, false, nil},
+		{"write multiple response", frame(0x10, 0, 0, 0, 1), false, []byte{0x10, 0, 0, 0, 1}, false, nil},
+		{"exception response", frame(0x83, 0x02), false, []byte{0x83, 0x02}, false, nil},
+		{"largest request", frame(append([]byte{0x10, 0, 0, 0, 123, 246}, make([]byte, 246)...)...), true, append([]byte{0x10, 0, 0, 0, 123, 246}, make([]byte, 246)...), false, nil},
+		{"largest response", frame(append([]byte{0x03, 250}, make([]byte, 250)...)...), false, append([]byte{0x03, 250}, make([]byte, 250)...), false, nil},
+		{"checksum mismatch", corrupt(frame(0x03, 0, 0, 0, 2)), true, nil, true, errFrame},
+		{"truncated frame", frame(0x03, 0, 0, 0, 2)[:5], true, nil, true, io.ErrUnexpectedEOF},
+		{"unsupported function", frame(0x2B, 0x0E), true, nil, true, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, uid, pdu, err := rtu{}.decode(bufio.NewReader(bytes.NewReader(tt.adu)), tt.request)
+			switch {
+			case tt.fail && err == nil:
+				t.Fatal("expected an error")
+			case tt.err != nil && !errors.Is(err, tt.err):
+				t.Fatalf("expected error %v, got %v", tt.err, err)
+			case !tt.fail && err != nil:
+				t.Fatalf("unexpected error %v", err)
+			case !tt.fail && (uid != 1 || !bytes.Equal(pdu, tt.pdu)):
+				t.Fatalf("expected unit 1 and pdu % X, got unit %v and pdu % X", tt.pdu, uid, pdu)
+			}
+		})
+	}
+}
+
+func TestRtuDecodeByteCount(t *testing.T) {
+	tests := []struct {
+		name    string
+		adu     []byte
+		request bool
+	}{
+		{"write multiple request", []byte{1, 0x10, 0, 0, 0, 1, 255}, true},
+		{"read write request", []byte{1, 0x17, 0, 0, 0, 1, 0, 0, 0, 1, 255}, true},
+		{"read response", []byte{1, 0x03, 255}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the frame is padded, so that the announced length can be read
+			adu := append(tt.adu, make([]byte, 300)...)
+			if _, _, _, err := (rtu{}).decode(bufio.NewReader(bytes.NewReader(adu)), tt.request); err == nil {
+				t.Fatal("expected an error for a byte count exceeding the maximum frame length")
+			}
+		})
+	}
+}
+
+func TestMbapDecode(t *testing.T) {
+	pdu := []byte{0x03, 0, 0, 0, 2}
+	tid, uid, got, err := mbap{}.decode(bufio.NewReader(bytes.NewReader(mbap{}.encode(7, 1, pdu))), true)
+	if err != nil || tid != 7 || uid != 1 || !bytes.Equal(got, pdu) {
+		t.Fatalf("unexpected result %v %v % X %v", tid, uid, got, err)
+	}
+	for _, l := range []byte{0, 1, 255} {
+		adu := append([]byte{0, 7, 0, 0, 0, l, 1}, make([]byte, 300)...)
+		if _, _, _, err := (mbap{}).decode(bufio.NewReader(bytes.NewReader(adu)), true); err == nil {
+			t.Fatalf("expected an error for length %v", l)
+		}
+	}
+	adu := mbap{}.encode(7, 1, pdu)
+	adu[3] = 1
+	if _, _, _, err := (mbap{}).decode(bufio.NewReader(bytes.NewReader(adu)), true); err != errFrame {
+		t.Fatalf("expected %v for a foreign protocol id, got %v", errFrame, err)
+	}
+}
@@ -0,0 +1,227 @@
+package sunspec
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/GoAethereal/cancel"
+)
+
+// Gateway is a server handler exposing the models of downstream devices through a single server.
+// The models of all attached devices are merged into the server´s device.
+// Write requests are forwarded to the downstream device, while read requests are served
+// from a cache, which is refreshed once the values exceed the maximum age.
+// Requests for models not attached to the gateway are served from memory.
+// The unit id of the requests is not considered, all unit ids address the same merged device.
+// Downstream devices to be told apart by the clients require servers of their own.
+//
+//	g := &sunspec.Gateway{MaxAge: time.Second}
+//	g.Attach(ctx, s, c, defs...)
+//	s.Serve(ctx, g.Handle)
+type Gateway struct {
+	// MaxAge is the maximum age of cached point values served to clients.
+	// Older values are read from the downstream device beforehand, zero forwards every read.
+	MaxAge time.Duration
+
+	mtx     sync.Mutex
+	mirrors map[Model]*mirror
+}
+
+// mirror links a model of the server to the model of a downstream device.
+// The mirrors of a single downstream device share its lock, guarding the transactions
+// with the device as well as the cached values.
+type mirror struct {
+	mtx    *sync.Mutex
+	client *Client
+	def    Definition
+	src    Model
+	dst    Model
+	// points maps the points of the server to the ones of the downstream device.
+	points  map[Point]Point
+	fetched map[Point]time.Time
+}
+
+var _ Definition = (*mirror)(nil)
+
+// ID returns the model identifier of the downstream model.
+func (m *mirror) ID() uint16 { return m.def.ID() }
+
+// Instance derives a copy of the downstream model starting at the address adr.
+// The values of the downstream points are assumed, so that repeating elements are instantiated accordingly.
+func (m *mirror) Instance(adr uint16, callback func(pts []Point) error) (Model, error) {
+	var src Points
+	iterate(m.src, func(g Group) error {
+		src = append(src, g.Points()...)
+		return nil
+	})
+	m.points = make(map[Point]Point, len(src))
+	dst, err := m.def.Instance(adr, func(pts []Point) error {
+		for _, p := range pts {
+			if len(src) == 0 {
+				return fmt.Errorf("%w: downstream model %v does not match its definition", ErrVerification, m.def.ID())
+			}
+			if err := transfer(p, src[0]); err != nil {
+				return err
+			}
+			m.points[p], src = src[0], src[1:]
+		}
+		if callback != nil {
+			return callback(pts)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	m.dst, m.fetched = dst, make(map[Point]time.Time, len(m.points))
+	return dst, nil
+}
+
+// transfer copies the value of point src into point dst.
+func transfer(dst, src Point) error {
+	buf := make([]byte, 2*src.Quantity())
+	if err := src.encode(buf); err != nil {
+		return err
+	}
+	return dst.decode(buf)
+}
+
+// Attach scans the downstream device using the client c and loads its models into the server s.
+// Only models with a matching definition are attached, all others are skipped.
+// The models are appended to the ones of the server, regardless of the unit id of the downstream device.
+// The client must stay connected for as long as the gateway is in use.
+func (g *Gateway) Attach(ctx cancel.Context, s *Server, c *Client, defs ...Definition) error {
+	if err := c.Scan(ctx, defs...); err != nil {
+		return err
+	}
+	var (
+		mirrors []Definition
+		mtx     = new(sync.Mutex)
+	)
+	for _, m := range c.Models() {
+		for _, def := range defs {
+			if def.ID() == m.ID().Get() {
+				mirrors = append(mirrors, &mirror{mtx: mtx, client: c, def: def, src: m})
+				break
+			}
+		}
+	}
+	// the server waits for requests in progress, hence it must be loaded without holding the lock
+	if err := s.Load(mirrors...); err != nil {
+		return err
+	}
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	if g.mirrors == nil {
+		g.mirrors = make(map[Model]*mirror)
+	}
+	now := time.Now()
+	for _, def := range mirrors {
+		m := def.(*mirror)
+		for p := range m.points {
+			m.fetched[p] = now
+		}
+		g.mirrors[m.dst] = m
+	}
+	return nil
+}
+
+// Handle processes the request, forwarding it to the downstream devices as required.
+// Errors returned by the downstream device, including modbus exceptions, are passed on to the client.
+// Requests to different downstream devices are processed concurrently.
+func (g *Gateway) Handle(ctx cancel.Context, req Request) error {
+	r, ok := req.(*request)
+	if !ok {
+		return errors.New("sunspec: the gateway only handles requests issued by the server")
+	}
+	for _, sub := range r.split() {
+		g.mtx.Lock()
+		m := g.mirrors[sub.model]
+		g.mtx.Unlock()
+		var err error
+		switch {
+		case m == nil:
+			err = g.memory(sub)
+		case sub.writing:
+			err = g.write(ctx, m, sub)
+		default:
+			err = g.read(ctx, m, sub)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// memory serves the request for a model not attached to the gateway from the server´s memory.
+func (g *Gateway) memory(req *request) error {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	if err := req.Ingest(); err != nil {
+		return err
+	}
+	return req.Flush()
+}
+
+// read refreshes all outdated points of the request from the downstream device before flushing them.
+func (g *Gateway) read(ctx cancel.Context, m *mirror, req *request) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	var stale bool
+	for _, p := range req.points {
+		stale = stale || time.Since(m.fetched[p]) >= g.MaxAge
+	}
+	if stale {
+		src := make([]Index, len(req.points))
+		for i, p := range req.points {
+			src[i] = m.points[p]
+		}
+		if _, err := m.client.Read(ctx, src...); err != nil {
+			return err
+		}
+		now := time.Now()
+		for _, p := range req.points {
+			if err := transfer(p, m.points[p]); err != nil {
+				return err
+			}
+			m.fetched[p] = now
+		}
+	}
+	return req.Flush()
+}
+
+// write ingests the request and sends the values to the downstream device.
+// If the downstream device fails, the previous values are restored.
+func (g *Gateway) write(ctx cancel.Context, m *mirror, req *request) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	old := make([]byte, 2*req.points.Quantity())
+	if err := req.points.encode(old); err != nil {
+		return err
+	}
+	if err := req.Ingest(); err != nil {
+		return err
+	}
+	src := make([]Index, len(req.points))
+	for i, p := range req.points {
+		src[i] = m.points[p]
+		if err := transfer(m.points[p], p); err != nil {
+			return err
+		}
+	}
+	if _, err := m.client.Write(ctx, src...); err != nil {
+		req.points.decode(old)
+		for _, p := range req.points {
+			transfer(m.points[p], p)
+		}
+		return err
+	}
+	now := time.Now()
+	for _, p := range req.points {
+		m.fetched[p] = now
+	}
+	return req.Flush()
+}
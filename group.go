@@ -43,7 +43,9 @@ func iterate(g Group, callback func(g Group) error) error {
 		return err
 	}
 	for _, g := range g.Groups() {
-		iterate(g, callback)
+		if err := iterate(g, callback); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -70,7 +72,12 @@ func (g *group) Name() string { return g.name }
 func (g *group) Atomic() bool { return g.atomic }
 
 // Origin returns the group´s parent container.
-func (g *group) Origin() Group { return g.origin }
+func (g *group) Origin() Group {
+	if g.origin == nil {
+		return nil
+	}
+	return g.origin
+}
 
 // Point returns the first immediate point identified by name.
 func (g *group) Point(name string) Point { return g.points.Point(name) }
@@ -0,0 +1,19 @@
+package sunspec
+
+import "github.com/GoAethereal/cancel"
+
+// Handler processes a received sunspec server request.
+type Handler func(ctx cancel.Context, req Request) error
+
+// Middleware wraps a handler adding behavior like logging, metrics or authorization.
+// A middleware may decide to not call next, thereby rejecting the request.
+type Middleware func(next Handler) Handler
+
+// Chain wraps the handler h with the given middleware.
+// The first middleware is the outermost, meaning it is the first to receive the request.
+func Chain(h Handler, mw ...Middleware) Handler {
+	for i := len(mw) - 1; i >= 0; i-- {
+		h = mw[i](h)
+	}
+	return h
+}
@@ -45,6 +45,8 @@ func intersect(a, b Index) bool {
 //  I{address: 0; quantity: 7}
 //  J{address: 8; quantity: 2}
 func merge(idx []Index) []Index {
+	// the caller´s slice is left untouched
+	idx = append([]Index(nil), idx...)
 	sort.Slice(idx, func(i, j int) bool { return idx[i].Address() < idx[j].Address() })
 	var merged []Index
 	curr := index{address: idx[0].Address(), quantity: idx[0].Quantity()}
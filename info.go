@@ -0,0 +1,98 @@
+package sunspec
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/GoAethereal/cancel"
+)
+
+// DeviceInfo is the identity of a device as described by its common model (1).
+type DeviceInfo struct {
+	// Manufacturer is the well known value registered with sunspec (Mn).
+	Manufacturer string
+	// Model is the manufacturer specific model (Md).
+	Model string
+	// Options are the manufacturer specific options (Opt), empty if not implemented.
+	Options string
+	// Version is the manufacturer specific version (Vr).
+	Version string
+	// Serial is the manufacturer specific serial number (SN).
+	Serial string
+	// Address is the modbus device address (DA).
+	Address uint16
+}
+
+// Info returns the identity of the device from the current values of its common model.
+// The strings are stripped of their trailing NUL and space padding.
+func Info(d Device) (DeviceInfo, error) {
+	m := d.Model(1)
+	if m == nil {
+		return DeviceInfo{}, fmt.Errorf("%w: the device does not contain the common model", ErrUnknownAddress)
+	}
+	var i DeviceInfo
+	for name, v := range map[string]*string{
+		"Mn":  &i.Manufacturer,
+		"Md":  &i.Model,
+		"Opt": &i.Options,
+		"Vr":  &i.Version,
+		"SN":  &i.Serial,
+	} {
+		if p, ok := m.Point(name).(String); ok {
+			*v = trim(p.Get())
+		}
+	}
+	if p, ok := m.Point("DA").(Uint16); ok {
+		i.Address = p.Get()
+	}
+	return i, nil
+}
+
+// Info reads the common model from the server, returning the identity of the device.
+func (c *Client) Info(ctx cancel.Context) (DeviceInfo, error) {
+	m := c.Model(1)
+	if m == nil {
+		return DeviceInfo{}, fmt.Errorf("%w: the device does not contain the common model", ErrUnknownAddress)
+	}
+	if _, err := c.Read(ctx, m); err != nil {
+		return DeviceInfo{}, err
+	}
+	return Info(c)
+}
+
+// SetInfo populates the common model of the server with the identity of the device.
+// The model must have been loaded beforehand. While serving the change should be
+// applied in conjunction with the handler, e.g. inside Store.Update.
+func (s *Server) SetInfo(i DeviceInfo) error {
+	m := s.Model(1)
+	if m == nil {
+		return fmt.Errorf("%w: the device does not contain the common model", ErrUnknownAddress)
+	}
+	for _, f := range [...]struct {
+		name, v string
+	}{
+		{"Mn", i.Manufacturer},
+		{"Md", i.Model},
+		{"Opt", i.Options},
+		{"Vr", i.Version},
+		{"SN", i.Serial},
+	} {
+		p, ok := m.Point(f.name).(String)
+		if !ok {
+			if f.v == "" {
+				continue
+			}
+			return fmt.Errorf("%w: the common model does not contain the point %v", ErrUnknownAddress, f.name)
+		}
+		if err := p.Set(f.v); err != nil {
+			return err
+		}
+	}
+	if p, ok := m.Point("DA").(Uint16); ok {
+		return p.Set(i.Address)
+	}
+	return nil
+}
+
+// trim strips the trailing NUL and space padding of a string point´s value.
+func trim(s string) string { return strings.TrimRight(s, "\x00 ") }
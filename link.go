@@ -0,0 +1,185 @@
+package sunspec
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/GoAethereal/cancel"
+	"github.com/GoAethereal/modbus"
+)
+
+// requester is the modbus master used by the client.
+type requester interface {
+	Ready() bool
+	Disconnect()
+	ReadHoldingRegisters(ctx cancel.Context, uid byte, address, quantity uint16) (values []byte, err error)
+	WriteMultipleRegisters(ctx cancel.Context, uid byte, address uint16, values []byte) (err error)
+}
+
+var (
+	_ requester = (*modbus.Client)(nil)
+	_ requester = (*link)(nil)
+)
+
+// link is a modbus master using its own connection handling,
+// allowing for transports not supported by the modbus package, e.g. tls, rtu framing or udp.
+// Requests are processed sequentially.
+type link struct {
+	dial   func(ctx cancel.Context) (net.Conn, error)
+	framer framer
+	// datagram specifies whether each read returns exactly one frame, as for udp.
+	datagram bool
+	mtx      sync.Mutex
+	con      net.Conn
+	r        *bufio.Reader
+	tid      uint16
+}
+
+// newLink returns a link connecting to the endpoint using the given network, framing and,
+// if cfg is not nil, tls.
+func newLink(network, endpoint string, f framer, cfg *tls.Config) *link {
+	return &link{framer: f, datagram: network == "udp", dial: func(ctx cancel.Context) (net.Conn, error) {
+		c, cancel := cancel.Promote(ctx)
+		defer cancel()
+		con, err := new(net.Dialer).DialContext(c, network, endpoint)
+		if err != nil || cfg == nil {
+			return con, err
+		}
+		// the handshake is performed with the first request
+		return tls.Client(con, cfg), nil
+	}}
+}
+
+// Ready returns true if the link is connected.
+func (l *link) Ready() bool {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	return l.con != nil
+}
+
+// Disconnect closes the connection.
+func (l *link) Disconnect() {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	l.close()
+}
+
+func (l *link) close() {
+	if l.con != nil {
+		l.con.Close()
+		l.con, l.r = nil, nil
+	}
+}
+
+// request sends the pdu to the connected endpoint returning the data of the response.
+// The connection is established on demand and dropped after transmission errors.
+func (l *link) request(ctx cancel.Context, uid, code byte, data []byte) (res []byte, err error) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	if l.con == nil {
+		if l.con, err = l.dial(ctx); err != nil {
+			l.con = nil
+			return nil, err
+		}
+		l.r = bufio.NewReader(l.con)
+	}
+	con := l.con
+	// the watch-dog aborts the transmission when the context is canceled
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		select {
+		case <-ctx.Done():
+			con.SetDeadline(time.Unix(1, 0))
+		case <-stop:
+		}
+	}()
+	defer func() {
+		close(stop)
+		wg.Wait()
+		if err != nil {
+			var ex modbus.Exception
+			if !errors.As(err, &ex) {
+				l.close()
+			}
+		}
+	}()
+	l.tid++
+	if _, err := con.Write(l.framer.encode(l.tid, uid, append([]byte{code}, data...))); err != nil {
+		return nil, err
+	}
+	for {
+		r := l.r
+		if l.datagram {
+			buf := make([]byte, 512)
+			n, err := con.Read(buf)
+			if err != nil {
+				return nil, err
+			}
+			r = bufio.NewReader(bytes.NewReader(buf[:n]))
+		}
+		tid, id, pdu, err := l.framer.decode(r, false)
+		switch {
+		case err == errFrame && l.datagram:
+			continue
+		case err != nil:
+			return nil, err
+		case l.framer.transactional() && tid != l.tid:
+			// responses to previous, aborted requests are skipped
+			continue
+		case uid != 0 && id != uid:
+			return nil, errors.New("sunspec: received mismatching response")
+		case pdu[0] == code|0x80 && len(pdu) == 2:
+			return nil, modbus.Exception(pdu[1])
+		case pdu[0] != code:
+			return nil, errors.New("sunspec: received mismatching response")
+		}
+		return pdu[1:], nil
+	}
+}
+
+// ReadHoldingRegisters reads from 1 to 125 (quantity) contiguous holding registers starting at address.
+func (l *link) ReadHoldingRegisters(ctx cancel.Context, uid byte, address, quantity uint16) (values []byte, err error) {
+	if quantity < 1 || quantity > 125 {
+		return nil, modbus.IllegalDataValue
+	}
+	req := make([]byte, 4)
+	binary.BigEndian.PutUint16(req[0:], address)
+	binary.BigEndian.PutUint16(req[2:], quantity)
+	res, err := l.request(ctx, uid, 0x03, req)
+	switch {
+	case err != nil:
+		return nil, err
+	case len(res) != 1+int(quantity)*2 || int(res[0]) != len(res)-1:
+		return nil, modbus.SlaveDeviceFailure
+	}
+	return res[1:], nil
+}
+
+// WriteMultipleRegisters writes from 1 to 123 contiguous registers starting at address.
+func (l *link) WriteMultipleRegisters(ctx cancel.Context, uid byte, address uint16, values []byte) (err error) {
+	quantity := len(values) / 2
+	if quantity < 1 || quantity > 123 || len(values)%2 != 0 {
+		return modbus.IllegalDataValue
+	}
+	req := make([]byte, 5, 5+len(values))
+	binary.BigEndian.PutUint16(req[0:], address)
+	binary.BigEndian.PutUint16(req[2:], uint16(quantity))
+	req[4] = byte(len(values))
+	res, err := l.request(ctx, uid, 0x10, append(req, values...))
+	switch {
+	case err != nil:
+		return err
+	case len(res) != 4 || binary.BigEndian.Uint16(res[0:]) != address || binary.BigEndian.Uint16(res[2:]) != uint16(quantity):
+		return modbus.SlaveDeviceFailure
+	}
+	return nil
+}
@@ -1,7 +1,7 @@
 package sunspec
 
 import (
-	"errors"
+	"fmt"
 	"regexp"
 )
 
@@ -45,17 +45,21 @@ func (def *ModelDef) ID() uint16 {
 func (def *ModelDef) Instance(adr uint16, callback func(pts []Point) error) (Model, error) {
 	m := &model{}
 
-	var iterate func(def GroupDef) (Group, error)
+	var iterate func(def GroupDef, o *group) (Group, error)
 
-	iterate = func(def GroupDef) (Group, error) {
+	iterate = func(def GroupDef, o *group) (Group, error) {
 		g := &group{
 			name:   def.Name,
 			atomic: bool(def.Atomic),
+			origin: o,
 		}
 		if m.group == nil {
 			m.group = g
 		}
 		for _, def := range def.Points {
+			if err := def.verify(); err != nil {
+				return nil, err
+			}
 			for c := m.count(def.Count); c != 0; c-- {
 				g.points = append(g.points, def.Instance(adr, g))
 				adr = ceil(g.points.Last())
@@ -68,7 +72,7 @@ func (def *ModelDef) Instance(adr uint16, callback func(pts []Point) error) (Mod
 		}
 		for _, def := range def.Groups {
 			for c := m.count(def.Count); c != 0; c-- {
-				x, err := iterate(def)
+				x, err := iterate(def, g)
 				if err != nil {
 					return nil, err
 				}
@@ -78,7 +82,7 @@ func (def *ModelDef) Instance(adr uint16, callback func(pts []Point) error) (Mod
 		return g, nil
 	}
 
-	if _, err := iterate(def.Group); err != nil {
+	if _, err := iterate(def.Group, nil); err != nil {
 		return nil, err
 	}
 
@@ -88,6 +92,31 @@ func (def *ModelDef) Instance(adr uint16, callback func(pts []Point) error) (Mod
 	return m, nil
 }
 
+// instance derives a verified model from the definition starting at the address adr.
+func instance(def Definition, adr uint16) (Model, error) {
+	m, err := def.Instance(adr, func(pts []Point) error { return nil })
+	if err != nil {
+		return nil, err
+	}
+	if err := Verify(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// relocate moves all points of the model m so that it starts at the address adr.
+func relocate(m Model, adr uint16) {
+	d := adr - m.Address()
+	iterate(m, func(g Group) error {
+		for _, p := range g.Points() {
+			if p, ok := p.(interface{ shift(d uint16) }); ok {
+				p.shift(d)
+			}
+		}
+		return nil
+	})
+}
+
 // model is internally used to build out a usable model.
 type model struct{ *group }
 
@@ -148,7 +177,7 @@ func (m *model) Length() Uint16 {
 // Verify validates the given model, checking for its compliance regarding the official sunspec specification.
 func Verify(m Model) error {
 	if m.Length().Get()+2 != m.Quantity() {
-		return errors.New("sunspec: Identifier L does not correlate with model quantity")
+		return fmt.Errorf("%w: identifier L does not correlate with model quantity", ErrVerification)
 	}
 	adr := m.Address()
 	// spec ref 4.2.1 "An ID MUST consist of only alphanumeric characters
@@ -156,19 +185,19 @@ func Verify(m Model) error {
 	r, _ := regexp.Compile("^([[:alnum:]]|_)+$")
 	return iterate(m, func(g Group) error {
 		switch {
+		case g.Points() == nil:
+			return fmt.Errorf("%w: the group %q is missing it´s point definition", ErrVerification, g.Name())
 		case g.Address() != adr:
-			return errors.New("sunspec: the given address range is not continuous")
+			return fmt.Errorf("%w: the address range of group %q is not continuous", ErrVerification, g.Name())
 		case !r.Match([]byte(g.Name())):
-			return errors.New("sunspec: the name is violating the specifications definition")
-		case g.Points() == nil:
-			return errors.New("sunspec: the group is missing it´s point definition")
+			return fmt.Errorf("%w: the name of group %q is violating the specifications definition", ErrVerification, g.Name())
 		}
 		for _, p := range g.Points() {
 			switch {
 			case p.Address() != adr:
-				return errors.New("sunspec: the given address range is not continuous")
+				return fmt.Errorf("%w: the address range of point %q is not continuous", ErrVerification, p.Name())
 			case !r.Match([]byte(p.Name())):
-				return errors.New("sunspec: the name is violating the specifications definition")
+				return fmt.Errorf("%w: the name of point %q is violating the specifications definition", ErrVerification, p.Name())
 			}
 			adr += p.Quantity()
 		}
@@ -196,7 +225,21 @@ func (mls Models) Model(id uint16) Model {
 	return nil
 }
 
-// Models returns all models from the device.
+// ModelAt returns the n-th (starting at 0) instance of the model identified by id.
+// Nil is returned if there are not enough instances.
+func (mls Models) ModelAt(id uint16, n int) Model {
+	for _, m := range mls {
+		if m.ID().Get() == id {
+			if n == 0 {
+				return m
+			}
+			n--
+		}
+	}
+	return nil
+}
+
+// Models returns all models from the device, keeping the order of the collection.
 // If ids are omitted all models are returned.
 func (mls Models) Models(ids ...uint16) Models {
 	if len(ids) == 0 {
@@ -214,6 +257,10 @@ func (mls Models) Models(ids ...uint16) Models {
 	return col
 }
 
+// Resolve retrieves the model, group or point referenced by path.
+// The returned Index can be asserted to Model, Group or Point respectively.
+func (mls Models) Resolve(path string) (Index, error) { return resolve(mls, path) }
+
 // Index returns the merged indexes of all models in the collection.
 func (mls Models) Index() []Index {
 	idx := make([]Index, 0, len(mls))
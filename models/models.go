@@ -0,0 +1,53 @@
+// Package models provides built-in definitions of commonly used sunspec models.
+// Only a subset of the official models is included, further definitions can be loaded
+// from their json schema using sunspec.ModelDef.
+package models
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"sort"
+
+	"github.com/TRICERA-energy/sunspec"
+)
+
+//go:embed json/*.json
+var files embed.FS
+
+// Definitions returns the definitions of all built-in models, ordered by their identifier.
+func Definitions() ([]sunspec.Definition, error) {
+	names, err := fs.Glob(files, "json/*.json")
+	if err != nil {
+		return nil, err
+	}
+	defs := make([]sunspec.Definition, 0, len(names))
+	for _, n := range names {
+		b, err := files.ReadFile(n)
+		if err != nil {
+			return nil, err
+		}
+		def := &sunspec.ModelDef{}
+		if err := json.Unmarshal(b, def); err != nil {
+			return nil, fmt.Errorf("models: %v: %w", n, err)
+		}
+		defs = append(defs, def)
+	}
+	sort.Slice(defs, func(i, j int) bool { return defs[i].ID() < defs[j].ID() })
+	return defs, nil
+}
+
+// Definition returns the built-in definition of the model id, or nil if there is none.
+func Definition(id uint16) sunspec.Definition {
+	defs, err := Definitions()
+	if err != nil {
+		return nil
+	}
+	for _, def := range defs {
+		if def.ID() == id {
+			return def
+		}
+	}
+	return nil
+}
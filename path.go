@@ -0,0 +1,99 @@
+package sunspec
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// segment is a single element of a path as used by resolve.
+type segment struct {
+	raw   string
+	name  string
+	index int
+}
+
+// String formats the segment as found in the path.
+func (s segment) String() string { return s.raw }
+
+// parse splits the given path into its segments.
+// Each segment consists of a name and an optional zero based repetition index, e.g.:
+//
+//	705/crv[2]/pt[5]/V
+func parse(path string) ([]segment, error) {
+	var segs []segment
+	for _, s := range strings.Split(strings.Trim(path, "/"), "/") {
+		seg := segment{raw: s, name: s}
+		if i := strings.IndexByte(s, '['); i >= 0 {
+			if !strings.HasSuffix(s, "]") {
+				return nil, fmt.Errorf("sunspec: path segment %q is missing the closing bracket", s)
+			}
+			n, err := strconv.Atoi(s[i+1 : len(s)-1])
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("sunspec: path segment %q has an invalid index", s)
+			}
+			seg.name, seg.index = s[:i], n
+		}
+		if seg.name == "" {
+			return nil, fmt.Errorf("sunspec: path %q contains an empty segment", path)
+		}
+		segs = append(segs, seg)
+	}
+	return segs, nil
+}
+
+// resolve retrieves the model, group or point referenced by path.
+// The first segment identifies the model either by its id or by the name of its top level group,
+// all subsequent segments identify the groups and finally the point inside that model.
+// An optional index selects the n-th repetition (starting at 0) of equally named elements,
+// which applies to repeated models, repeating groups and points with a count.
+// For instance:
+//
+//	103/W
+//	160[1]/module[3]/DCW
+//	705/crv[2]/pt[5]/V
+func resolve(d Device, path string) (Index, error) {
+	segs, err := parse(path)
+	if err != nil {
+		return nil, err
+	}
+	var g Group
+	if id, err := strconv.ParseUint(segs[0].name, 10, 16); err == nil {
+		if m := d.ModelAt(uint16(id), segs[0].index); m != nil {
+			g = m
+		}
+	} else {
+		for _, m := range d.Models() {
+			if m.Name() == segs[0].name {
+				if segs[0].index == 0 {
+					g = m
+					break
+				}
+				segs[0].index--
+			}
+		}
+	}
+	if g == nil {
+		return nil, fmt.Errorf("sunspec: path segment %q does not identify a model instance of the device", segs[0])
+	}
+	for i, seg := range segs[1:] {
+		if pts := g.Points(seg.name); len(pts) != 0 {
+			switch {
+			case i != len(segs)-2:
+				return nil, fmt.Errorf("sunspec: path segment %q is a point and can not contain further elements", seg)
+			case seg.index >= len(pts):
+				return nil, fmt.Errorf("sunspec: path segment %q exceeds the %v available point repetitions", seg, len(pts))
+			}
+			return pts[seg.index], nil
+		}
+		gps := g.Groups(seg.name)
+		switch {
+		case len(gps) == 0:
+			return nil, fmt.Errorf("sunspec: path segment %q does not identify a point or group of %q", seg, g.Name())
+		case seg.index >= len(gps):
+			return nil, fmt.Errorf("sunspec: path segment %q exceeds the %v available group repetitions", seg, len(gps))
+		}
+		g = gps[seg.index]
+	}
+	return g, nil
+}
@@ -1,5 +1,10 @@
 package sunspec
 
+import (
+	"encoding/binary"
+	"fmt"
+)
+
 // Point defines the generic behavior all sunspec types have in common.
 type Point interface {
 	// Index defines the locality of the point in a modbus address space.
@@ -8,16 +13,25 @@ type Point interface {
 	Name() string
 	// Valid specifies whether the underlying value is implemented by the device.
 	Valid() bool
+	// Invalidate sets the point´s value to the unimplemented value of its type,
+	// marking it as not implemented by the device.
+	Invalidate()
 	// Origin returns the point´s associated group
 	Origin() Group
 	// Static specifies whether the point is expected to stay constant - not change over time.
 	Static() bool
 	// Writable specifies whether the point can be written to.
 	Writable() bool
+	// Units returns the unit of measure of the point´s scaled value, e.g. "W".
+	Units() string
+	// Valuer provides generic access to the point´s value.
+	Valuer
 	// encode puts the point´s value into a buffer.
 	encode(buf []byte) error
 	// decode sets the point´s value from a buffer.
 	decode(buf []byte) error
+	// validate checks whether the value in buf complies with the point´s definition.
+	validate(buf []byte) error
 }
 
 // PointDef is the definition of a sunspec point element.
@@ -28,6 +42,8 @@ type PointDef struct {
 	Count       interface{} `json:"count,omitempty"`
 	Size        uint16      `json:"size"`
 	ScaleFactor interface{} `json:"sf,omitempty"`
+	Minimum     *float64    `json:"min,omitempty"`
+	Maximum     *float64    `json:"max,omitempty"`
 	Units       string      `json:"units,omitempty"`
 	Writable    writable    `json:"access,omitempty"`
 	Mandatory   mandatory   `json:"mandatory,omitempty"`
@@ -47,11 +63,14 @@ func (def *PointDef) Instance(adr uint16, o Group) Point {
 		writable: bool(def.Writable),
 		origin:   o,
 		address:  adr,
+		min:      def.Minimum,
+		max:      def.Maximum,
+		units:    def.Units,
 	}
 	f := scale{def.ScaleFactor}
 	s := make(Symbols, len(def.Symbols))
 	for _, sym := range def.Symbols {
-		s[sym.Value] = &symbol{sym.Name, sym.Value}
+		s[sym.Value] = &symbol{sym.Name, sym.Value, sym.Label, sym.Description}
 	}
 
 	init := map[string]func() Point{
@@ -72,16 +91,32 @@ func (def *PointDef) Instance(adr uint16, o Group) Point {
 		"bitfield64": func() Point { return &tBitfield64{p, toUint64(def.Value), s} },
 		"enum16":     func() Point { return &tEnum16{p, toUint16(def.Value), s} },
 		"enum32":     func() Point { return &tEnum32{p, toUint32(def.Value), s} },
-		"string":     func() Point { return &tString{p, append(make([]byte, 0, def.Size*2), toByteS(def.Value)...)} },
+		"string":     func() Point { return &tString{p, padded(toByteS(def.Value), int(def.Size)*2)} },
 		"float32":    func() Point { return &tFloat32{p, toFloat32(def.Value)} },
 		"float64":    func() Point { return &tFloat64{p, toFloat64(def.Value)} },
-		"ipaddr":     func() Point { return &tIpaddr{p, [4]byte{}} },    // initial value ToDo
-		"ipv6addr":   func() Point { return &tIpv6addr{p, [16]byte{}} }, // initial value ToDo
-		"eui48":      func() Point { return &tEui48{p, [8]byte{}} },     // initial value ToDo
+		"ipaddr":     func() Point { r, _ := toIpaddr(def.Value); return &tIpaddr{p, r} },
+		"ipv6addr":   func() Point { r, _ := toIpv6addr(def.Value); return &tIpv6addr{p, r} },
+		"eui48":      func() Point { r, _ := toEui48(def.Value); return &tEui48{p, r} },
 	}
 	return init[def.Type]()
 }
 
+// verify checks the value of the definition, which is not representable by the instanced point if invalid.
+func (def *PointDef) verify() (err error) {
+	switch def.Type {
+	case "ipaddr":
+		_, err = toIpaddr(def.Value)
+	case "ipv6addr":
+		_, err = toIpv6addr(def.Value)
+	case "eui48":
+		_, err = toEui48(def.Value)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: the value of point %q is invalid: %v", ErrVerification, def.Name, err)
+	}
+	return nil
+}
+
 // point is internally used to build out a useable model
 type point struct {
 	name     string
@@ -89,11 +124,16 @@ type point struct {
 	static   bool
 	writable bool
 	address  uint16
+	min, max *float64
+	units    string
 }
 
 // Address returns the modbus starting address of the point.
 func (p *point) Address() uint16 { return p.address }
 
+// shift moves the point by d registers inside the modbus address space.
+func (p *point) shift(d uint16) { p.address += d }
+
 // ID returns the point´s identifier
 func (p *point) Name() string { return p.name }
 
@@ -103,10 +143,132 @@ func (p *point) Writable() bool { return p.writable }
 // Origin returns the point´s associated group
 func (p *point) Origin() Group { return p.origin }
 
+// Units returns the unit of measure of the point´s scaled value, e.g. "W".
+func (p *point) Units() string { return p.units }
+
 // Static specifies whether the points underlying data is supposed to be constant,
 // meaning it is not supposed to change over time.
 func (p *point) Static() bool { return p.static }
 
+// validate checks whether the value in buf complies with the point´s definition.
+// Types without any constraints accept all values.
+func (p *point) validate(buf []byte) error { return nil }
+
+// bound checks whether the (scaled) value v lies within the boundaries declared in the definition.
+func (p *point) bound(v float64) error {
+	switch {
+	case p.min != nil && v < *p.min:
+		return fmt.Errorf("%w: %v falls below the minimum %v of %q", ErrIllegalValue, v, *p.min, p.name)
+	case p.max != nil && v > *p.max:
+		return fmt.Errorf("%w: %v exceeds the maximum %v of %q", ErrIllegalValue, v, *p.max, p.name)
+	}
+	return nil
+}
+
+// factorOf returns the point holding the scale factor of p, or nil if it has no variable factor.
+func factorOf(p Point) Point {
+	if s, ok := p.(interface{ sunssf(p Point) Sunssf }); ok {
+		if sf := s.sunssf(p); sf != nil {
+			return sf
+		}
+	}
+	return nil
+}
+
+// freeze returns a detached copy of the point, its scale factor being fixed to the current value.
+func freeze(p Point) Point {
+	if s, ok := p.(Scalable); ok {
+		return rescale(p, s.Factor())
+	}
+	return detach(p)
+}
+
+// rescale returns a detached copy of the point, its scale factor being fixed to f.
+func rescale(p Point, f int16) Point {
+	c := detach(p)
+	if s, ok := c.(interface{ fix(f int16) }); ok {
+		s.fix(f)
+	}
+	return c
+}
+
+// detach returns a copy of the point, which does not share its value with the original.
+func detach(p Point) Point {
+	switch t := p.(type) {
+	case *tInt16:
+		c := *t
+		return &c
+	case *tInt32:
+		c := *t
+		return &c
+	case *tInt64:
+		c := *t
+		return &c
+	case *tPad:
+		c := *t
+		return &c
+	case *tSunssf:
+		c := *t
+		return &c
+	case *tUint16:
+		c := *t
+		return &c
+	case *tUint32:
+		c := *t
+		return &c
+	case *tUint64:
+		c := *t
+		return &c
+	case *tAcc16:
+		c := *t
+		return &c
+	case *tAcc32:
+		c := *t
+		return &c
+	case *tAcc64:
+		c := *t
+		return &c
+	case *tCount:
+		c := *t
+		return &c
+	case *tBitfield16:
+		c := *t
+		return &c
+	case *tBitfield32:
+		c := *t
+		return &c
+	case *tBitfield64:
+		c := *t
+		return &c
+	case *tEnum16:
+		c := *t
+		return &c
+	case *tEnum32:
+		c := *t
+		return &c
+	case *tFloat32:
+		c := *t
+		return &c
+	case *tFloat64:
+		c := *t
+		return &c
+	case *tIpaddr:
+		c := *t
+		return &c
+	case *tIpv6addr:
+		c := *t
+		return &c
+	case *tEui48:
+		c := *t
+		return &c
+	case *tString:
+		c := *t
+		c.data = append(make([]byte, 0, cap(t.data)), t.data...)
+		return &c
+	}
+	return p
+}
+
 // Points is a collection wrapper for multiple Points.
 // Offering functionalities applicable for them.
 type Points []Point
@@ -183,6 +345,29 @@ func (pts Points) decode(buf []byte) error {
 	return nil
 }
 
+// validate checks the values stored in the buffer against the definitions of the points in the collection.
+// The points themselves are not altered.
+// Scale factors written by the same request apply to the validation of their dependent points.
+func (pts Points) validate(buf []byte) error {
+	sfs := make(map[Point]int16)
+	for i, off := 0, 0; i < len(pts); off, i = off+2*int(pts[i].Quantity()), i+1 {
+		if _, ok := pts[i].(Sunssf); ok {
+			sfs[pts[i]] = int16(binary.BigEndian.Uint16(buf[off:]))
+		}
+	}
+	for _, p := range pts {
+		v := p
+		if f, ok := sfs[factorOf(p)]; ok {
+			v = rescale(p, f)
+		}
+		if err := v.validate(buf); err != nil {
+			return err
+		}
+		buf = buf[2*p.Quantity():]
+	}
+	return nil
+}
+
 // encode puts the values of the points in the collection into the buffer.
 func (pts Points) encode(buf []byte) error {
 	for _, p := range pts {
@@ -9,6 +9,10 @@ type Request interface {
 	Ingest() error
 	// Points returns all points that are affected by the request.
 	Points() Points
+	// Peer returns the client which sent the request.
+	Peer() Peer
+	// Unit returns the modbus unit id addressed by the request.
+	Unit() byte
 	// Flush ends the request.
 	// It is mandatory to do so after finishing the processing.
 	Flush() error
@@ -18,6 +22,33 @@ type request struct {
 	points  Points
 	writing bool
 	buffer  []byte
+	layout  Models
+	model   Model
+	peer    Peer
+	unit    byte
+	hooks   []hook
+}
+
+// hook is a write hook registered for a point or group.
+type hook struct {
+	index Index
+	fn    WriteHook
+}
+
+// WriteHook is called after the points of a client write request were ingested.
+// Returning an error vetoes the write, restoring the previous values of all points in the request.
+// The error is passed on like any error of the handler.
+type WriteHook func(w Write) error
+
+// Write describes the points written by a client request.
+type Write struct {
+	// Peer is the client which sent the request.
+	Peer Peer
+	// Points are the written points holding their new values.
+	Points Points
+	// Old are detached copies of the written points holding their previous values.
+	// They are in the same order as Points.
+	Old Points
 }
 
 // Writing specifies whether the request is attempting to set point values.
@@ -25,18 +56,79 @@ func (r *request) Writing() bool { return r.writing }
 
 // Ingest updates the affected point values in accordance to the request.
 // For read only requests no change is applied to the points.
+// Registered write hooks are called after the values were set.
 func (r *request) Ingest() error {
 	if !r.Writing() {
 		return nil
 	}
-	return r.points.decode(r.buffer)
+	if len(r.hooks) == 0 {
+		return r.points.decode(r.buffer)
+	}
+	old := make(Points, len(r.points))
+	for i, p := range r.points {
+		old[i] = detach(p)
+	}
+	if err := r.points.decode(r.buffer); err != nil {
+		return err
+	}
+	for _, h := range r.hooks {
+		w := Write{Peer: r.peer}
+		for i, p := range r.points {
+			if intersect(h.index, p) {
+				w.Points, w.Old = append(w.Points, p), append(w.Old, old[i])
+			}
+		}
+		if len(w.Points) == 0 {
+			continue
+		}
+		if err := h.fn(w); err != nil {
+			buf := make([]byte, 2*old.Quantity())
+			if err := old.encode(buf); err != nil {
+				return err
+			}
+			if err := r.points.decode(buf); err != nil {
+				return err
+			}
+			return err
+		}
+	}
+	return nil
 }
 
 // Points returns all points that are affected by the request.
 func (r *request) Points() Points { return r.points.Points() }
 
+// Peer returns the client which sent the request.
+func (r *request) Peer() Peer { return r.peer }
+
+// Unit returns the modbus unit id addressed by the request.
+func (r *request) Unit() byte { return r.unit }
+
 // Close ends the request.
 // It is mandatory to do so after finishing the processing.
 func (r *request) Flush() error {
 	return r.points.encode(r.buffer)
 }
+
+// split divides the request into one request per affected model of the layout.
+// The resulting requests share the buffer with the original one.
+func (r *request) split() (reqs []*request) {
+	var off uint16
+	for _, p := range r.points {
+		var m Model
+		for _, x := range r.layout {
+			if intersect(x, p) {
+				m = x
+				break
+			}
+		}
+		if l := len(reqs); l == 0 || reqs[l-1].model != m {
+			reqs = append(reqs, &request{writing: r.writing, buffer: r.buffer[2*off : 2*off], layout: r.layout, model: m, peer: r.peer, unit: r.unit, hooks: r.hooks})
+		}
+		sub := reqs[len(reqs)-1]
+		off += p.Quantity()
+		sub.points = append(sub.points, p)
+		sub.buffer = sub.buffer[:len(sub.buffer)+2*int(p.Quantity())]
+	}
+	return reqs
+}
@@ -0,0 +1,73 @@
+package sunspec
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/asn1"
+	"net"
+)
+
+// oidRole identifies the role extension of X.509 certificates as defined by Modbus/TCP Security.
+var oidRole = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 50316, 802, 1}
+
+// Security configures Modbus/TCP Security, i.e. modbus tcp secured by mutual TLS authentication.
+// The specification designates port 802 for secured connections.
+type Security struct {
+	// Certificates are presented to the other side of the connection.
+	// Servers require at least one certificate, clients must provide one when the server requests client authentication.
+	Certificates []tls.Certificate
+	// CAs is the pool of certificate authorities used for verifying the certificates of the other side.
+	// If nil, the host´s root certificate authorities are used.
+	CAs *x509.CertPool
+	// ClientAuth is the server´s policy for authenticating clients.
+	// The zero value defaults to tls.RequireAndVerifyClientCert as mandated by the specification.
+	ClientAuth tls.ClientAuthType
+	// ServerName is used by the client for verifying the server´s certificate.
+	// If empty, the host of the endpoint is used.
+	ServerName string
+}
+
+// client returns the tls configuration for connecting to the endpoint.
+func (s *Security) client(endpoint string) *tls.Config {
+	name := s.ServerName
+	if name == "" {
+		if host, _, err := net.SplitHostPort(endpoint); err == nil {
+			name = host
+		}
+	}
+	return &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: s.Certificates,
+		RootCAs:      s.CAs,
+		ServerName:   name,
+	}
+}
+
+// server returns the tls configuration for accepting client connections.
+func (s *Security) server() *tls.Config {
+	auth := s.ClientAuth
+	if auth == tls.NoClientCert {
+		auth = tls.RequireAndVerifyClientCert
+	}
+	return &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: s.Certificates,
+		ClientCAs:    s.CAs,
+		ClientAuth:   auth,
+	}
+}
+
+// role extracts the role from the certificate´s role extension.
+// An empty string is returned if the certificate does not contain a valid role.
+func role(cert *x509.Certificate) string {
+	for _, ext := range cert.Extensions {
+		if !ext.Id.Equal(oidRole) {
+			continue
+		}
+		var r string
+		if _, err := asn1.Unmarshal(ext.Value, &r); err == nil {
+			return r
+		}
+	}
+	return ""
+}
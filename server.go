@@ -1,96 +1,413 @@
 package sunspec
 
 import (
+	"encoding/binary"
+	"errors"
+	"sync"
+
 	"github.com/GoAethereal/cancel"
 	"github.com/GoAethereal/modbus"
 )
 
 // Server is a sunspec compliant server.
+// Models can be loaded, removed and replaced while serving, changing the layout of the device.
 type Server struct {
 	server
-	models Models
+	// mtx guards the models, busy is held by all requests in flight,
+	// thereby preventing changes of the layout while processing them.
+	mtx        sync.RWMutex
+	busy       sync.RWMutex
+	models     Models
+	middleware []Middleware
+	routes     map[uint16]Handler
+	hooks      []hook
+	policy     *policy
 }
 
 var _ Device = (*Server)(nil)
 
 // Model returns the first model identifies by id.
-func (s *Server) Model(id uint16) Model { return s.models[1 : len(s.models)-1].Model(id) }
+func (s *Server) Model(id uint16) Model { return s.device().Model(id) }
 
-// Models returns all models from the device.
-func (s *Server) Models(ids ...uint16) Models { return s.models[1 : len(s.models)-1].Models(ids...) }
+// ModelAt returns the n-th (starting at 0) instance of the model identified by id.
+func (s *Server) ModelAt(id uint16, n int) Model { return s.device().ModelAt(id, n) }
 
-// Serve instantiates the model, as declared in the definition and starts serving it to connected clients.
-// The handler function is called for any incoming client request.
-func (s *Server) Serve(ctx cancel.Context, handler func(ctx cancel.Context, req Request) error, defs ...Definition) error {
-	// append the start marker
-	s.models = append(Models(nil), marker(0))
-	adr := ceil(s.models.First())
-	for _, def := range defs {
-		m, err := def.Instance(adr, func(pts []Point) error { return nil })
-		if err != nil {
+// Models returns all models from the device, ordered by their modbus address.
+func (s *Server) Models(ids ...uint16) Models { return s.device().Models(ids...) }
+
+// device returns the loaded models without the start and end markers.
+func (s *Server) device() Models {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	if len(s.models) < 2 {
+		return nil
+	}
+	return s.models[1 : len(s.models)-1]
+}
+
+// acquire returns the current layout of the device including the start and end markers.
+// The layout is guaranteed to stay unchanged until release is called.
+func (s *Server) acquire() (mls Models, release func()) {
+	s.busy.RLock()
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	return s.models, s.busy.RUnlock
+}
+
+// change applies fn to a copy of the current layout, the returned layout replaces the current one.
+// All requests in flight are completed beforehand.
+func (s *Server) change(fn func(mls Models) (Models, error)) error {
+	s.busy.Lock()
+	defer s.busy.Unlock()
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if len(s.models) == 0 {
+		// start with the start marker followed by the end marker
+		s.models = Models{marker(0), header(2, 0xFFFF, 0)}
+	}
+	mls, err := fn(append(Models(nil), s.models...))
+	if err != nil {
+		return err
+	}
+	s.models = mls
+	return nil
+}
+
+// Resolve retrieves the model, group or point referenced by path.
+func (s *Server) Resolve(path string) (Index, error) { return resolve(s, path) }
+
+// Use appends middleware to the server, which wraps the handler of every request in the given order.
+// It must be called before serving.
+func (s *Server) Use(mw ...Middleware) { s.middleware = append(s.middleware, mw...) }
+
+// Handle registers the handler h for all points belonging to the models identified by id,
+// in place of the handler passed to Serve. Requests spanning multiple models are split,
+// calling the respective handler for each model. It must be called before serving.
+func (s *Server) Handle(id uint16, h Handler) {
+	if s.routes == nil {
+		s.routes = make(map[uint16]Handler)
+	}
+	s.routes[id] = h
+}
+
+// OnWrite registers the hook fn for the point or group referenced by path (see Resolve).
+// The hook is called after a client request wrote the point or any point inside the group including its sub-groups.
+// Hooks of a group are called once per request, receiving all affected points at once.
+// The models must be loaded in advance using Load. Hooks of removed or replaced models are dropped.
+func (s *Server) OnWrite(path string, fn WriteHook) error {
+	idx, err := s.Resolve(path)
+	if err != nil {
+		return err
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.hooks = append(s.hooks, hook{index: idx, fn: fn})
+	return nil
+}
+
+// Restrict applies the access policy to all subsequent requests.
+// Passing nil removes the policy, granting full access to all clients.
+func (s *Server) Restrict(p *Policy) error {
+	var c *policy
+	if p != nil {
+		var err error
+		if c, err = p.compile(); err != nil {
 			return err
 		}
-		if err := Verify(m); err != nil {
-			return err
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.policy = c
+	return nil
+}
+
+// Load instantiates the models, as declared in the definitions, and appends them to the device.
+// Loading the models ahead of serving grants access to the points, e.g. to register callbacks.
+// Models can also be loaded while serving, clients will see them with their next scan.
+// It must not be called from within a handler.
+func (s *Server) Load(defs ...Definition) error {
+	return s.change(func(mls Models) (Models, error) {
+		end := mls.Last()
+		adr := end.Address()
+		mls = mls[:len(mls)-1]
+		for _, def := range defs {
+			m, err := instance(def, adr)
+			if err != nil {
+				return nil, err
+			}
+			adr = ceil(m)
+			mls = append(mls, m)
+		}
+		// append the end-marker
+		relocate(end, adr)
+		return append(mls, end), nil
+	})
+}
+
+// Remove takes the given model instance off the device while serving.
+// The subsequent models are moved up, closing the gap in the modbus address space.
+// It must not be called from within a handler.
+func (s *Server) Remove(m Model) error {
+	return s.change(func(mls Models) (Models, error) {
+		i := s.locate(mls, m)
+		if i < 0 {
+			return nil, errors.New("sunspec: the model is not part of the device")
+		}
+		s.drop(m)
+		for _, x := range mls[i+1:] {
+			relocate(x, x.Address()-m.Quantity())
+		}
+		return append(mls[:i], mls[i+1:]...), nil
+	})
+}
+
+// Replace swaps the given model instance for a new instance of the definition while serving.
+// The new model takes the place of the old one, moving the subsequent models as required.
+// It must not be called from within a handler.
+func (s *Server) Replace(m Model, def Definition) (n Model, err error) {
+	err = s.change(func(mls Models) (Models, error) {
+		i := s.locate(mls, m)
+		if i < 0 {
+			return nil, errors.New("sunspec: the model is not part of the device")
+		}
+		if n, err = instance(def, m.Address()); err != nil {
+			return nil, err
 		}
-		adr = ceil(m)
-		s.models = append(s.models, m)
+		s.drop(m)
+		for adr, x := ceil(n), mls[i+1:]; len(x) != 0; adr, x = ceil(x[0]), x[1:] {
+			relocate(x[0], adr)
+		}
+		mls[i] = n
+		return mls, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// locate returns the position of the model m in the layout, excluding the markers.
+// If the model is not found -1 is returned.
+func (s *Server) locate(mls Models, m Model) int {
+	for i := 1; i < len(mls)-1; i++ {
+		if mls[i] == m {
+			return i
+		}
+	}
+	return -1
+}
+
+// drop removes all hooks registered for points of the model m.
+func (s *Server) drop(m Model) {
+	var i int
+	for _, h := range s.hooks {
+		if !intersect(m, h.index) {
+			s.hooks[i] = h
+			i++
+		}
+	}
+	s.hooks = s.hooks[:i]
+}
+
+// Serve instantiates the model, as declared in the definition and starts serving it to connected clients.
+// Models already loaded using Load are served in front of them.
+// The handler function is called for any incoming client request. If the handler is nil,
+// a Store is used, simply keeping the point values in memory.
+// If the handler returns an error wrapping a modbus.Exception, the exception is returned to the client,
+// e.g. modbus.IllegalDataValue for out of range values. Any other error is reported as modbus.SlaveDeviceFailure.
+func (s *Server) Serve(ctx cancel.Context, handler Handler, defs ...Definition) error {
+	if err := s.Load(defs...); err != nil {
+		return err
 	}
-	// append the end-marker
-	s.models = append(s.models, header(adr, 0xFFFF, 0))
+	if handler == nil {
+		handler = new(Store).Handle
+	}
+	return s.serve(ctx, s.acquire, s.authorize, s.dispatch(handler))
+}
 
-	return s.serve(ctx, s.models, handler)
+// Shutdown gracefully stops the server. No further connections are accepted
+// and idle connections are closed immediately, while connections processing a request
+// are closed after responding. Serve returns once all connections are closed.
+// If ctx is canceled before, all remaining connections are closed forcefully and an error is returned.
+func (s *Server) Shutdown(ctx cancel.Context) error { return s.shutdown(ctx) }
+
+// Peers returns the descriptions of all currently connected clients.
+func (s *Server) Peers() []Peer { return s.peers() }
+
+// authorize applies the access policy to the request addressing the registers idx.
+// It is called before the registers are resolved, thus denied clients learn nothing about the layout.
+func (s *Server) authorize(r *request, idx Index) error {
+	s.mtx.RLock()
+	p := s.policy
+	s.mtx.RUnlock()
+	if p == nil {
+		return nil
+	}
+	return p.authorize(r, idx)
+}
+
+// dispatch builds the handler for all incoming requests.
+// Requests are routed to the handlers registered per model, all of them wrapped by the middleware.
+func (s *Server) dispatch(fallback Handler) Handler {
+	fallback = Chain(fallback, s.middleware...)
+	routes := make(map[uint16]Handler, len(s.routes))
+	for id, h := range s.routes {
+		routes[id] = Chain(h, s.middleware...)
+	}
+	return func(ctx cancel.Context, req Request) error {
+		r, ok := req.(*request)
+		if !ok {
+			return errors.New("sunspec: the request was not issued by the server")
+		}
+		s.mtx.RLock()
+		r.hooks = s.hooks
+		s.mtx.RUnlock()
+		if len(routes) == 0 {
+			return fallback(ctx, r)
+		}
+		for _, sub := range r.split() {
+			h := fallback
+			if id := sub.model.ID(); id != nil {
+				if r, ok := routes[id.Get()]; ok {
+					h = r
+				}
+			}
+			if err := h(ctx, sub); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
 }
 
 type server interface {
-	serve(ctx cancel.Context, d Device, handler func(ctx cancel.Context, req Request) error) error
+	serve(ctx cancel.Context, layout func() (Models, func()), authorize func(r *request, idx Index) error, handler Handler) error
+	shutdown(ctx cancel.Context) error
+	peers() []Peer
 }
 
 var _ server = (*mbServer)(nil)
 
 type mbServer struct {
-	modbus.Server
+	endpoint
 }
 
-func newModbusServer(endpoint string) *mbServer {
-	return &mbServer{
-		Server: modbus.Server{Config: modbus.Config{
-			Mode:     "tcp",
-			Kind:     "tcp",
-			Endpoint: endpoint,
-		}},
+func newModbusServer(o Config) *mbServer {
+	s := &mbServer{endpoint: endpoint{
+		address: o.Endpoint,
+		limit:   o.MaxConnections,
+		idle:    o.IdleTimeout,
+	}}
+	if o.Security != nil {
+		s.tls = o.Security.server()
 	}
+	s.network, s.framer, s.err = o.transport()
+	return s
 }
 
-func (s *mbServer) serve(ctx cancel.Context, d Device, handler func(ctx cancel.Context, req Request) error) error {
-	return s.Serve(ctx, &modbus.Mux{
-		ReadHoldingRegisters: func(ctx cancel.Context, _ byte, address, quantity uint16) (res []byte, ex modbus.Exception) {
-			pts, err := collect(d, index{address: address, quantity: quantity})
-			if err != nil {
-				return nil, modbus.IllegalDataAddress
-			}
-			req := &request{points: pts, writing: false, buffer: make([]byte, 2*pts.Quantity())}
-			if err := handler(ctx, req); err != nil {
-				return nil, modbus.SlaveDeviceFailure
-			}
-			return req.buffer, 0
-		},
-		WriteMultipleRegisters: func(ctx cancel.Context, _ byte, address uint16, values []byte) (ex modbus.Exception) {
-			pts, err := collect(d, index{address: address, quantity: uint16(len(values) / 2)})
-			if err != nil {
+func (s *mbServer) serve(ctx cancel.Context, layout func() (Models, func()), authorize func(r *request, idx Index) error, handler Handler) error {
+	read := func(ctx cancel.Context, uid byte, address, quantity uint16) (res []byte, ex modbus.Exception) {
+		d, release := layout()
+		defer release()
+		idx := index{address: address, quantity: quantity}
+		req := &request{writing: false, layout: d, peer: peerOf(ctx), unit: uid}
+		if err := authorize(req, idx); err != nil {
+			return nil, exception(err)
+		}
+		pts, err := collect(d, idx)
+		if err != nil {
+			return nil, exception(err)
+		}
+		req.points, req.buffer = pts, make([]byte, 2*pts.Quantity())
+		if err := handler(ctx, req); err != nil {
+			return nil, exception(err)
+		}
+		return req.buffer, 0
+	}
+	write := func(ctx cancel.Context, uid byte, address uint16, values []byte) (ex modbus.Exception) {
+		d, release := layout()
+		defer release()
+		idx := index{address: address, quantity: uint16(len(values) / 2)}
+		req := &request{writing: true, buffer: values, layout: d, peer: peerOf(ctx), unit: uid}
+		if err := authorize(req, idx); err != nil {
+			return exception(err)
+		}
+		pts, err := collect(d, idx)
+		if err != nil {
+			return exception(err)
+		}
+		// ref 6.5.1 / 6.5.3: Unimplemented Registers / Writing a Read-Only Register
+		for _, p := range pts {
+			if !p.Valid() || !p.Writable() {
 				return modbus.IllegalDataAddress
 			}
-			// ref 6.5.1 / 6.5.3: Unimplemented Registers / Writing a Read-Only Register
-			for _, p := range pts {
-				if !p.Valid() || !p.Writable() {
-					return modbus.IllegalDataAddress
-				}
-			}
-			req := &request{points: pts, writing: true, buffer: values}
-			if err := handler(ctx, req); err != nil {
-				return modbus.SlaveDeviceFailure
+		}
+		// enumerations, bitfields and bounded set-points must comply with their definition
+		if err := pts.validate(values); err != nil {
+			return exception(err)
+		}
+		req.points = pts
+		if err := handler(ctx, req); err != nil {
+			return exception(err)
+		}
+		return 0
+	}
+	return s.listen(ctx, &mux{modbus.Mux{
+		ReadHoldingRegisters: func(ctx cancel.Context, uid byte, address, quantity uint16) (res []byte, ex modbus.Exception) {
+			return read(ctx, uid, address, quantity)
+		},
+		ReadInputRegisters: func(ctx cancel.Context, uid byte, address, quantity uint16) (res []byte, ex modbus.Exception) {
+			return read(ctx, uid, address, quantity)
+		},
+		WriteSingleRegister: func(ctx cancel.Context, uid byte, address, value uint16) (ex modbus.Exception) {
+			// points spanning multiple registers are not fully contained by a single register,
+			// hence the write is rejected as illegal data address
+			values := make([]byte, 2)
+			binary.BigEndian.PutUint16(values, value)
+			return write(ctx, uid, address, values)
+		},
+		WriteMultipleRegisters: func(ctx cancel.Context, uid byte, address uint16, values []byte) (ex modbus.Exception) {
+			return write(ctx, uid, address, values)
+		},
+		ReadWriteMultipleRegisters: func(ctx cancel.Context, uid byte, rAddress, rQuantity, wAddress uint16, values []byte) (res []byte, ex modbus.Exception) {
+			// the write operation is performed before the read
+			if ex := write(ctx, uid, wAddress, values); ex != 0 {
+				return nil, ex
 			}
-			return 0
+			return read(ctx, uid, rAddress, rQuantity)
 		},
-	})
+	}})
+}
+
+// mux wraps the modbus.Mux correcting the request validation of function code 0x17,
+// which must check the byte count against the write quantity instead of the read quantity.
+type mux struct{ modbus.Mux }
+
+// Handle dispatches incoming requests depending on their function code.
+func (h *mux) Handle(ctx cancel.Context, uid, code byte, req []byte) (res []byte, ex modbus.Exception) {
+	if code != 0x17 || h.ReadWriteMultipleRegisters == nil {
+		return h.Mux.Handle(ctx, uid, code, req)
+	}
+	if len(req) < 11 {
+		return nil, modbus.IllegalDataAddress
+	}
+	rAddress := binary.BigEndian.Uint16(req[0:])
+	rQuantity := binary.BigEndian.Uint16(req[2:])
+	wAddress := binary.BigEndian.Uint16(req[4:])
+	wQuantity := binary.BigEndian.Uint16(req[6:])
+	switch {
+	case int(wQuantity)*2 != int(req[8]) || int(req[8]) != len(req[9:]):
+		return nil, modbus.IllegalDataValue
+	case rQuantity < 1 || rQuantity > 125 || wQuantity < 1 || wQuantity > 121:
+		return nil, modbus.IllegalDataValue
+	case uint32(rAddress)+uint32(rQuantity) > 0x10000 || uint32(wAddress)+uint32(wQuantity) > 0x10000:
+		return nil, modbus.IllegalDataAddress
+	}
+	if res, ex = h.ReadWriteMultipleRegisters(ctx, uid, rAddress, rQuantity, wAddress, req[9:]); ex != 0 {
+		return nil, ex
+	}
+	if len(res) != 2*int(rQuantity) {
+		return nil, modbus.SlaveDeviceFailure
+	}
+	return append([]byte{byte(len(res))}, res...), 0
 }
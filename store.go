@@ -0,0 +1,80 @@
+package sunspec
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/GoAethereal/cancel"
+)
+
+// Store is a ready to use server handler keeping the point values in memory.
+// Write requests are ingested and read requests are answered with the current point values.
+// The store serializes all access to the point values, hence the application should
+// change them using Update while serving. The zero value is ready to use:
+//
+//	st := new(sunspec.Store)
+//	s.Serve(ctx, st.Handle, defs...)
+type Store struct {
+	mtx       sync.Mutex
+	callbacks map[Point][]func(p Point)
+}
+
+// OnChange registers the callback fn, which is called whenever a client changed the value of the point p.
+// Points are available after the server loaded the models, see Server.Load.
+func (st *Store) OnChange(p Point, fn func(p Point)) {
+	st.mtx.Lock()
+	defer st.mtx.Unlock()
+	if st.callbacks == nil {
+		st.callbacks = make(map[Point][]func(p Point))
+	}
+	st.callbacks[p] = append(st.callbacks[p], fn)
+}
+
+// Update calls fn while holding exclusive access to the point values.
+// It is used by the application to safely change point values while serving.
+func (st *Store) Update(fn func() error) error {
+	st.mtx.Lock()
+	defer st.mtx.Unlock()
+	return fn()
+}
+
+// Handle processes the request ingesting written values and flushing read values.
+// After a write the change callbacks of all points with a modified value are called.
+func (st *Store) Handle(_ cancel.Context, req Request) error {
+	if !req.Writing() {
+		return st.Update(req.Flush)
+	}
+	var (
+		changed   Points
+		callbacks [][]func(p Point)
+	)
+	if err := st.Update(func() error {
+		pts := req.Points()
+		old, now := make([]byte, 2*pts.Quantity()), make([]byte, 2*pts.Quantity())
+		if err := pts.encode(old); err != nil {
+			return err
+		}
+		if err := req.Ingest(); err != nil {
+			return err
+		}
+		if err := pts.encode(now); err != nil {
+			return err
+		}
+		for _, p := range pts {
+			l := 2 * p.Quantity()
+			if cb := st.callbacks[p]; len(cb) != 0 && !bytes.Equal(old[:l], now[:l]) {
+				changed, callbacks = append(changed, p), append(callbacks, cb)
+			}
+			old, now = old[l:], now[l:]
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	for i, p := range changed {
+		for _, fn := range callbacks[i] {
+			fn(p)
+		}
+	}
+	return nil
+}
@@ -45,3 +45,26 @@ func header(adr, id, l uint16) Model {
 		},
 	}
 }
+
+// common returns a prototype of the common model (1) for identifying a device.
+// It only holds the points required for the identification, hence no definition is needed.
+func common(adr uint16) Model {
+	str := func(name string, adr, l uint16) Point {
+		return &tString{data: make([]byte, 2*l), point: point{name: name, static: true, address: adr}}
+	}
+	return &model{
+		&group{
+			name: "common",
+			points: Points{
+				&tUint16{point: point{name: "ID", static: true, address: adr}},
+				&tUint16{point: point{name: "L", static: true, address: adr + 1}},
+				str("Mn", adr+2, 16),
+				str("Md", adr+18, 16),
+				str("Opt", adr+34, 8),
+				str("Vr", adr+42, 8),
+				str("SN", adr+50, 16),
+				&tUint16{point: point{name: "DA", address: adr + 66}},
+			},
+		},
+	}
+}
@@ -1,9 +1,18 @@
 package sunspec
 
+import "fmt"
+
 // Symbol defines an element in the enumeration of a point.
+// For bitfields the value is the bit position of the symbol.
 type Symbol interface {
+	// Name returns the identifier of the symbol, e.g. "THROTTLED".
 	Name() string
+	// Value returns the enumerated value of the symbol.
 	Value() uint32
+	// Label returns the human readable name of the symbol.
+	Label() string
+	// Description returns the description of the symbol.
+	Description() string
 }
 
 // SymbolDef is the definition of a sunspec symbol element.
@@ -20,12 +29,18 @@ type SymbolDef struct {
 type symbol struct {
 	name  string
 	value uint32
+	label string
+	desc  string
 }
 
 func (s *symbol) Name() string { return s.name }
 
 func (s *symbol) Value() uint32 { return s.value }
 
+func (s *symbol) Label() string { return s.label }
+
+func (s *symbol) Description() string { return s.desc }
+
 type Symbols map[uint32]Symbol
 
 // Symbol retrieves the first symbol from the collection, identified by the given name.
@@ -54,3 +69,59 @@ func (sym Symbols) Symbols(names ...string) Symbols {
 	}
 	return col
 }
+
+// name returns the name of the symbol enumerating v.
+// Values without a defined symbol are named "unknown(v)".
+func (sym Symbols) name(v uint32) string {
+	if s, ok := sym[v]; ok && s != nil {
+		return s.Name()
+	}
+	return fmt.Sprintf("unknown(%v)", v)
+}
+
+// value returns the enumerated value of the symbol identified by name.
+func (sym Symbols) value(p Point, name string) (uint32, error) {
+	if s := sym.Symbol(name); s != nil {
+		return s.Value(), nil
+	}
+	return 0, fmt.Errorf("%w: %q is not a defined symbol of %q", ErrIllegalValue, name, p.Name())
+}
+
+// mask returns the bits enumerated by the named symbols, bit positions must be below size.
+func (sym Symbols) mask(p Point, size uint32, names []string) (uint64, error) {
+	var m uint64
+	for _, name := range names {
+		b, err := sym.value(p, name)
+		if err != nil {
+			return 0, err
+		}
+		if b >= size {
+			return 0, fmt.Errorf("%w: bit %v of symbol %q exceeds %q", ErrOutOfRange, b, name, p.Name())
+		}
+		m |= 1 << b
+	}
+	return m, nil
+}
+
+// defined checks whether v is an enumerated value of the collection.
+// An empty collection, e.g. for vendor specific enumerations, permits any value.
+func (sym Symbols) defined(p Point, v uint32) error {
+	if _, ok := sym[v]; ok || len(sym) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %v is not a defined symbol of %q", ErrIllegalValue, v, p.Name())
+}
+
+// bits checks whether only bit positions enumerated by the collection are set in v.
+// An empty collection, e.g. for vendor specific bitfields, permits any value.
+func (sym Symbols) bits(p Point, v uint64) error {
+	if len(sym) == 0 {
+		return nil
+	}
+	for b := uint32(0); b < 64; b++ {
+		if _, ok := sym[b]; v&(1<<b) != 0 && !ok {
+			return fmt.Errorf("%w: bit %v is not a defined symbol of %q", ErrIllegalValue, b, p.Name())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,388 @@
+package sunspec
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/GoAethereal/cancel"
+	"github.com/GoAethereal/modbus"
+)
+
+// Peer describes a client connected to the server.
+type Peer struct {
+	// Addr is the network address of the client.
+	Addr net.Addr
+	// Since is the point in time the client connected.
+	Since time.Time
+	// Requests is the number of requests received from the client.
+	Requests uint64
+	// Certificate is the certificate the client authenticated with.
+	// It is only set for connections secured by Modbus/TCP Security.
+	Certificate *x509.Certificate
+	// Role is the role granted to the client by the role extension of its certificate.
+	// It is empty if the connection is not secured or the certificate does not contain a role.
+	Role string
+}
+
+// session is the context handed to the modbus handler for the requests of a client.
+type session struct {
+	cancel.Context
+	peer func() Peer
+}
+
+// peerOf returns the client associated with the context.
+func peerOf(ctx cancel.Context) Peer {
+	if s, ok := ctx.(*session); ok {
+		return s.peer()
+	}
+	return Peer{}
+}
+
+// conn is a client connection accepted by the endpoint.
+type conn struct {
+	net.Conn
+	since time.Time
+	// mtx guards the state, busy is set while a request is processed.
+	mtx      sync.Mutex
+	requests uint64
+	busy     bool
+	closing  bool
+	cert     *x509.Certificate
+}
+
+// peer returns the current description of the connected client.
+func (c *conn) peer() Peer {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	p := Peer{Addr: c.RemoteAddr(), Since: c.since, Requests: c.requests, Certificate: c.cert}
+	if c.cert != nil {
+		p.Role = role(c.cert)
+	}
+	return p
+}
+
+// handshakeTimeout bounds the tls handshake of connections without idle timeout.
+const handshakeTimeout = 10 * time.Second
+
+// handshake completes the tls handshake of secured connections, recording the client´s certificate.
+// The handshake is bounded by the timeout, or by handshakeTimeout if it is zero.
+func (c *conn) handshake(timeout time.Duration) error {
+	tc, ok := c.Conn.(*tls.Conn)
+	if !ok {
+		return nil
+	}
+	if timeout <= 0 {
+		timeout = handshakeTimeout
+	}
+	c.SetDeadline(time.Now().Add(timeout))
+	defer c.SetDeadline(time.Time{})
+	if err := tc.Handshake(); err != nil {
+		return err
+	}
+	if certs := tc.ConnectionState().PeerCertificates; len(certs) > 0 {
+		c.mtx.Lock()
+		c.cert = certs[0]
+		c.mtx.Unlock()
+	}
+	return nil
+}
+
+// begin marks the connection as busy counting the request.
+// It returns false if the connection is shutting down.
+func (c *conn) begin() bool {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if c.busy = !c.closing; c.busy {
+		c.requests++
+	}
+	return c.busy
+}
+
+// end marks the connection as idle, it returns false if the connection is shutting down.
+func (c *conn) end() bool {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.busy = false
+	return !c.closing
+}
+
+// drain closes the connection once the request in progress is finished.
+func (c *conn) drain() {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.closing = true
+	if !c.busy {
+		c.Close()
+	}
+}
+
+// endpoint manages the listener and all client connections of a server.
+type endpoint struct {
+	address string
+	// limit is the maximum number of simultaneous connections, zero means unlimited.
+	limit int
+	// idle is the duration after which a silent connection is closed, zero means never.
+	idle time.Duration
+	// tls secures all connections if not nil.
+	tls *tls.Config
+	// network is either tcp or udp.
+	network string
+	framer  framer
+	// err is the configuration error reported when listening.
+	err error
+
+	mtx     sync.Mutex
+	wg      sync.WaitGroup
+	l       net.Listener
+	pc      net.PacketConn
+	conns   map[*conn]struct{}
+	closing bool
+}
+
+// listen accepts incoming tcp connections, serving all of them using the handler h.
+// If the endpoint is secured, the connections are established using tls.
+// The function blocks until the context is canceled or the endpoint was shut down
+// and all connections are closed.
+func (e *endpoint) listen(ctx cancel.Context, h modbus.Handler) error {
+	if e.err != nil {
+		return e.err
+	}
+	if e.network == "udp" {
+		return e.listenPacket(ctx, h)
+	}
+	l, err := net.Listen("tcp", e.address)
+	if err != nil {
+		return err
+	}
+	if e.tls != nil {
+		l = tls.NewListener(l, e.tls)
+	}
+	e.mtx.Lock()
+	e.l, e.closing, e.conns = l, false, make(map[*conn]struct{})
+	e.mtx.Unlock()
+	// the watch-dog stops the listener when the context is canceled
+	sig := cancel.New().Propagate(ctx)
+	defer sig.Cancel()
+	go func() {
+		<-sig.Done()
+		l.Close()
+	}()
+	defer e.wg.Wait()
+	var delay time.Duration
+	for {
+		con, err := l.Accept()
+		if err != nil {
+			select {
+			case <-ctx.Done():
+				return nil
+			default:
+			}
+			e.mtx.Lock()
+			closing := e.closing
+			e.mtx.Unlock()
+			if closing {
+				return nil
+			}
+			// the listener is retried with an increasing delay, e.g. when running out of file descriptors
+			if delay == 0 {
+				delay = 5 * time.Millisecond
+			} else if delay *= 2; delay > time.Second {
+				delay = time.Second
+			}
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(delay):
+			}
+			continue
+		}
+		delay = 0
+		c := &conn{Conn: con, since: time.Now()}
+		if !e.register(c) {
+			con.Close()
+			continue
+		}
+		go func() {
+			defer e.wg.Done()
+			defer e.unregister(c)
+			e.handle(ctx, c, h)
+		}()
+	}
+}
+
+// listenPacket receives datagrams, each containing a single request, serving them using the handler h.
+// Since there are no connections, neither the connection limit nor the idle timeout apply.
+// The function blocks until the context is canceled or the endpoint was shut down.
+func (e *endpoint) listenPacket(ctx cancel.Context, h modbus.Handler) error {
+	pc, err := net.ListenPacket("udp", e.address)
+	if err != nil {
+		return err
+	}
+	defer pc.Close()
+	e.mtx.Lock()
+	e.pc, e.closing, e.conns = pc, false, make(map[*conn]struct{})
+	e.mtx.Unlock()
+	e.wg.Add(1)
+	defer e.wg.Done()
+	// the watch-dog stops the receiver when the context is canceled
+	sig := cancel.New().Propagate(ctx)
+	defer sig.Cancel()
+	go func() {
+		<-sig.Done()
+		pc.Close()
+	}()
+	buf := make([]byte, 512)
+	for {
+		n, addr, err := pc.ReadFrom(buf)
+		if err != nil {
+			select {
+			case <-ctx.Done():
+				return nil
+			default:
+			}
+			e.mtx.Lock()
+			closing := e.closing
+			e.mtx.Unlock()
+			if closing {
+				return nil
+			}
+			return err
+		}
+		tid, uid, pdu, err := e.framer.decode(bufio.NewReader(bytes.NewReader(buf[:n])), true)
+		if err != nil {
+			continue
+		}
+		p := Peer{Addr: addr, Since: time.Now(), Requests: 1}
+		sess := &session{Context: sig, peer: func() Peer { return p }}
+		pc.WriteTo(e.framer.encode(tid, uid, respond(sess, h, uid, pdu)), addr)
+	}
+}
+
+// register adds the connection to the endpoint, respecting the connection limit.
+func (e *endpoint) register(c *conn) bool {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	if e.closing || (e.limit > 0 && len(e.conns) >= e.limit) {
+		return false
+	}
+	e.conns[c] = struct{}{}
+	e.wg.Add(1)
+	return true
+}
+
+// unregister removes the connection from the endpoint.
+func (e *endpoint) unregister(c *conn) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	delete(e.conns, c)
+}
+
+// peers returns the descriptions of all connected clients.
+func (e *endpoint) peers() []Peer {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	ps := make([]Peer, 0, len(e.conns))
+	for c := range e.conns {
+		ps = append(ps, c.peer())
+	}
+	return ps
+}
+
+// shutdown stops accepting new connections and closes all idle ones.
+// Connections processing a request are closed after sending the response.
+// If the context is canceled before all connections are closed, the remaining ones are closed forcefully.
+func (e *endpoint) shutdown(ctx cancel.Context) error {
+	e.mtx.Lock()
+	if e.l == nil && e.pc == nil {
+		e.mtx.Unlock()
+		return errors.New("sunspec: the server is not serving")
+	}
+	e.closing = true
+	if e.l != nil {
+		e.l.Close()
+	} else {
+		// the request in progress is answered before the receiver stops
+		e.pc.SetReadDeadline(time.Unix(1, 0))
+	}
+	for c := range e.conns {
+		c.drain()
+	}
+	e.mtx.Unlock()
+	done := make(chan struct{})
+	go func() {
+		e.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		e.mtx.Lock()
+		for c := range e.conns {
+			c.Close()
+		}
+		if e.pc != nil {
+			e.pc.Close()
+		}
+		e.mtx.Unlock()
+		// handlers still processing a request are not awaited, Serve returns once they are finished
+		return errors.New("sunspec: shutdown aborted, closing connections with requests in progress")
+	}
+}
+
+// handle processes all requests received by the connection using the framing of the endpoint.
+// Requests of a single connection are processed sequentially.
+func (e *endpoint) handle(ctx cancel.Context, c *conn, h modbus.Handler) {
+	sig := cancel.New().Propagate(ctx)
+	defer sig.Cancel()
+	go func() {
+		<-sig.Done()
+		c.Close()
+	}()
+	if err := c.handshake(e.idle); err != nil {
+		return
+	}
+	sess := &session{Context: sig, peer: c.peer}
+	r := bufio.NewReader(c)
+	for {
+		if e.idle > 0 {
+			c.SetReadDeadline(time.Now().Add(e.idle))
+		}
+		tid, uid, pdu, err := e.framer.decode(r, true)
+		switch {
+		case err == errFrame:
+			continue
+		case err != nil:
+			return
+		}
+		if !c.begin() {
+			return
+		}
+		_, err = c.Write(e.framer.encode(tid, uid, respond(sess, h, uid, pdu)))
+		if !c.end() || err != nil {
+			return
+		}
+	}
+}
+
+// respond passes the request pdu to the handler returning the response pdu.
+func respond(ctx cancel.Context, h modbus.Handler, uid byte, pdu []byte) []byte {
+	code := pdu[0]
+	if code >= 0x80 {
+		return []byte{code | 0x80, byte(modbus.IllegalFunction)}
+	}
+	res, ex := h.Handle(ctx, uid, code, pdu[1:])
+	switch {
+	case ex != 0:
+		return []byte{code | 0x80, byte(ex)}
+	case len(res) > 252:
+		return []byte{code | 0x80, byte(modbus.SlaveDeviceFailure)}
+	}
+	return append([]byte{code}, res...)
+}
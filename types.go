@@ -1,11 +1,14 @@
 package sunspec
 
 import (
+	"bytes"
 	"encoding/binary"
-	"errors"
 	"fmt"
 	"math"
 	"net"
+	"strconv"
+	"strings"
+	"unicode/utf8"
 )
 
 // Scalable defines the behavior of a point type which may be scaled using the definition:
@@ -27,24 +30,41 @@ func (s *scale) Scaled() bool {
 
 // factor returns the scale value of the point.
 func (s *scale) factor(p Point) int16 {
-	switch sf := s.f.(type) {
-	case int16:
+	if sf, ok := s.f.(int16); ok {
 		return sf
-	case Sunssf:
+	}
+	if sf := s.sunssf(p); sf != nil {
 		return sf.Get()
+	}
+	return 0
+}
+
+// sunssf returns the point holding the scale value of the point, resolving it by name on first use.
+// Nil is returned for constant or missing scale factors.
+func (s *scale) sunssf(p Point) Sunssf {
+	switch sf := s.f.(type) {
+	case Sunssf:
+		return sf
 	case string:
 		for g := p.Origin(); g != nil; g = g.Origin() {
 			for _, p := range g.Points() {
 				if p.Name() == sf {
 					if p, ok := p.(Sunssf); ok {
 						s.f = p
-						return p.Get()
+						return p
 					}
 				}
 			}
 		}
 	}
-	return 0
+	return nil
+}
+
+// fix replaces the scale factor by the constant f, detaching it from the point holding it.
+func (s *scale) fix(f int16) {
+	if s.f != nil {
+		s.f = f
+	}
 }
 
 // ****************************************************************************
@@ -74,6 +94,9 @@ var _ Int16 = (*tInt16)(nil)
 // Valid specifies whether the underlying value is implemented by the device.
 func (t *tInt16) Valid() bool { return t.Get() != -0x8000 }
 
+// Invalidate sets the point´s value to the unimplemented value of its type.
+func (t *tInt16) Invalidate() { t.data = -0x8000 }
+
 // String formats the point´s value as string.
 func (t *tInt16) String() string { return fmt.Sprintf("%v", t.Get()) }
 
@@ -91,6 +114,11 @@ func (t *tInt16) decode(buf []byte) error {
 	return t.Set(int16(binary.BigEndian.Uint16(buf)))
 }
 
+// validate checks whether the value in buf complies with the point´s definition.
+func (t *tInt16) validate(buf []byte) error {
+	return t.bound(float64(int16(binary.BigEndian.Uint16(buf))) * math.Pow10(int(t.Factor())))
+}
+
 // Set sets the point´s underlying value.
 func (t *tInt16) Set(v int16) error {
 	t.data = v
@@ -106,6 +134,32 @@ func (t *tInt16) Factor() int16 { return t.factor(t) }
 // Value returns the scaled value as defined by the specification.
 func (t *tInt16) Value() float64 { return float64(t.Get()) * math.Pow10(int(t.Factor())) }
 
+// Raw returns the point´s underlying value.
+func (t *tInt16) Raw() interface{} { return t.Get() }
+
+// Float returns the point´s numeric value, scaled by its factor.
+func (t *tInt16) Float() (float64, bool) {
+	if !t.Valid() {
+		return 0, false
+	}
+	return scaled(float64(t.Get()), t.Factor()), true
+}
+
+// SetFloat sets the point to the numeric value v, scaled by its factor.
+func (t *tInt16) SetFloat(v float64) error {
+	r, err := unscaled(t, v, t.Factor(), -math.MaxInt16, math.MaxInt16)
+	if err != nil {
+		return err
+	}
+	return t.Set(int16(r))
+}
+
+// Text returns the textual representation of the point´s value.
+func (t *tInt16) Text() string { return text(t) }
+
+// SetText sets the point´s value from its textual representation.
+func (t *tInt16) SetText(s string) error { return setText(t, s) }
+
 // ****************************************************************************
 
 // Int32 represents the sunspec type int32.
@@ -133,6 +187,9 @@ var _ (Int32) = (*tInt32)(nil)
 // Valid specifies whether the underlying value is implemented by the device.
 func (t *tInt32) Valid() bool { return t.Get() != -0x80000000 }
 
+// Invalidate sets the point´s value to the unimplemented value of its type.
+func (t *tInt32) Invalidate() { t.data = -0x80000000 }
+
 // String formats the point´s value as string.
 func (t *tInt32) String() string { return fmt.Sprintf("%v", t.Get()) }
 
@@ -150,6 +207,11 @@ func (t *tInt32) decode(buf []byte) error {
 	return t.Set(int32((binary.BigEndian.Uint32(buf))))
 }
 
+// validate checks whether the value in buf complies with the point´s definition.
+func (t *tInt32) validate(buf []byte) error {
+	return t.bound(float64(int32(binary.BigEndian.Uint32(buf))) * math.Pow10(int(t.Factor())))
+}
+
 // Set sets the point´s underlying value.
 func (t *tInt32) Set(v int32) error {
 	t.data = v
@@ -165,6 +227,32 @@ func (t *tInt32) Factor() int16 { return t.factor(t) }
 // Value returns the scaled value as defined by the specification.
 func (t *tInt32) Value() float64 { return float64(t.Get()) * math.Pow10(int(t.Factor())) }
 
+// Raw returns the point´s underlying value.
+func (t *tInt32) Raw() interface{} { return t.Get() }
+
+// Float returns the point´s numeric value, scaled by its factor.
+func (t *tInt32) Float() (float64, bool) {
+	if !t.Valid() {
+		return 0, false
+	}
+	return scaled(float64(t.Get()), t.Factor()), true
+}
+
+// SetFloat sets the point to the numeric value v, scaled by its factor.
+func (t *tInt32) SetFloat(v float64) error {
+	r, err := unscaled(t, v, t.Factor(), -math.MaxInt32, math.MaxInt32)
+	if err != nil {
+		return err
+	}
+	return t.Set(int32(r))
+}
+
+// Text returns the textual representation of the point´s value.
+func (t *tInt32) Text() string { return text(t) }
+
+// SetText sets the point´s value from its textual representation.
+func (t *tInt32) SetText(s string) error { return setText(t, s) }
+
 // ****************************************************************************
 
 // Int64 represents the sunspec type int64.
@@ -192,6 +280,9 @@ var _ Int64 = (*tInt64)(nil)
 // Valid specifies whether the underlying value is implemented by the device.
 func (t *tInt64) Valid() bool { return t.Get() != -0x8000000000000000 }
 
+// Invalidate sets the point´s value to the unimplemented value of its type.
+func (t *tInt64) Invalidate() { t.data = -0x8000000000000000 }
+
 // String formats the point´s value as string.
 func (t *tInt64) String() string { return fmt.Sprintf("%v", t.Get()) }
 
@@ -209,6 +300,11 @@ func (t *tInt64) decode(buf []byte) error {
 	return t.Set(int64(binary.BigEndian.Uint64(buf)))
 }
 
+// validate checks whether the value in buf complies with the point´s definition.
+func (t *tInt64) validate(buf []byte) error {
+	return t.bound(float64(int64(binary.BigEndian.Uint64(buf))) * math.Pow10(int(t.Factor())))
+}
+
 // Set sets the point´s underlying value.
 func (t *tInt64) Set(v int64) error {
 	t.data = v
@@ -224,6 +320,32 @@ func (t *tInt64) Factor() int16 { return t.factor(t) }
 // Value returns the scaled value as defined by the specification.
 func (t *tInt64) Value() float64 { return float64(t.Get()) * math.Pow10(int(t.Factor())) }
 
+// Raw returns the point´s underlying value.
+func (t *tInt64) Raw() interface{} { return t.Get() }
+
+// Float returns the point´s numeric value, scaled by its factor.
+func (t *tInt64) Float() (float64, bool) {
+	if !t.Valid() {
+		return 0, false
+	}
+	return scaled(float64(t.Get()), t.Factor()), true
+}
+
+// SetFloat sets the point to the numeric value v, scaled by its factor.
+func (t *tInt64) SetFloat(v float64) error {
+	r, err := unscaled(t, v, t.Factor(), -maxInt64, maxInt64)
+	if err != nil {
+		return err
+	}
+	return t.Set(int64(r))
+}
+
+// Text returns the textual representation of the point´s value.
+func (t *tInt64) Text() string { return text(t) }
+
+// SetText sets the point´s value from its textual representation.
+func (t *tInt64) SetText(s string) error { return setText(t, s) }
+
 // ****************************************************************************
 
 // Pad represents the sunspec type pad.
@@ -241,6 +363,9 @@ var _ Pad = (*tPad)(nil)
 // Valid specifies whether the underlying value is implemented by the device.
 func (t *tPad) Valid() bool { return false }
 
+// Invalidate has no effect, as padding is never implemented.
+func (t *tPad) Invalidate() {}
+
 // String formats the point´s value as string.
 func (t *tPad) String() string { return "" }
 
@@ -256,6 +381,30 @@ func (t *tPad) encode(buf []byte) error {
 // decode sets the point´s value from a buffer.
 func (t *tPad) decode(buf []byte) error { return nil }
 
+// Raw returns the point´s underlying value.
+func (t *tPad) Raw() interface{} { return nil }
+
+// Float returns the point´s numeric value.
+func (t *tPad) Float() (float64, bool) {
+	return 0, false
+}
+
+// SetFloat sets the point to the numeric value v.
+func (t *tPad) SetFloat(v float64) error {
+	return nonNumeric(t)
+}
+
+// Text returns the textual representation of the point´s value.
+func (t *tPad) Text() string { return "" }
+
+// SetText sets the point´s value from its textual representation.
+func (t *tPad) SetText(s string) error {
+	if s != "" {
+		return fmt.Errorf("%w: padding %q can not be set", ErrIllegalValue, t.Name())
+	}
+	return nil
+}
+
 // ****************************************************************************
 
 // Sunssf represents the sunspec type sunssf.
@@ -276,6 +425,9 @@ var _ Sunssf = (*tSunssf)(nil)
 // Valid specifies whether the underlying value is implemented by the device.
 func (t *tSunssf) Valid() bool { return t.Get() != -0x8000 }
 
+// Invalidate sets the point´s value to the unimplemented value of its type.
+func (t *tSunssf) Invalidate() { t.data = -0x8000 }
+
 // String formats the point´s value as string.
 func (t *tSunssf) String() string { return fmt.Sprintf("%v", t.Get()) }
 
@@ -296,7 +448,7 @@ func (t *tSunssf) decode(buf []byte) error {
 // set sets the point´s underlying value.
 func (t *tSunssf) set(v int16) error {
 	if v != -0x8000 && (v < -10 || v > 10) {
-		return errors.New("sunspec: value out of boundary")
+		return ErrOutOfRange
 	}
 	t.data = v
 	return nil
@@ -305,6 +457,32 @@ func (t *tSunssf) set(v int16) error {
 // Get returns the point´s underlying value.
 func (t *tSunssf) Get() int16 { return t.data }
 
+// Raw returns the point´s underlying value.
+func (t *tSunssf) Raw() interface{} { return t.Get() }
+
+// Float returns the point´s numeric value.
+func (t *tSunssf) Float() (float64, bool) {
+	if !t.Valid() {
+		return 0, false
+	}
+	return float64(t.Get()), true
+}
+
+// SetFloat sets the point to the numeric value v.
+func (t *tSunssf) SetFloat(v float64) error {
+	r, err := unscaled(t, v, 0, -math.MaxInt16, math.MaxInt16)
+	if err != nil {
+		return err
+	}
+	return t.set(int16(r))
+}
+
+// Text returns the textual representation of the point´s value.
+func (t *tSunssf) Text() string { return text(t) }
+
+// SetText sets the point´s value from its textual representation.
+func (t *tSunssf) SetText(s string) error { return setText(t, s) }
+
 // ****************************************************************************
 
 // Uint16 represents the sunspec type uint16.
@@ -332,6 +510,9 @@ var _ Uint16 = (*tUint16)(nil)
 // Valid specifies whether the underlying value is implemented by the device.
 func (t *tUint16) Valid() bool { return t.Get() != 0xFFFF }
 
+// Invalidate sets the point´s value to the unimplemented value of its type.
+func (t *tUint16) Invalidate() { t.data = 0xFFFF }
+
 // String formats the point´s value as string.
 func (t *tUint16) String() string { return fmt.Sprintf("%v", t.Get()) }
 
@@ -349,6 +530,11 @@ func (t *tUint16) decode(buf []byte) error {
 	return t.Set(binary.BigEndian.Uint16(buf))
 }
 
+// validate checks whether the value in buf complies with the point´s definition.
+func (t *tUint16) validate(buf []byte) error {
+	return t.bound(float64(binary.BigEndian.Uint16(buf)) * math.Pow10(int(t.Factor())))
+}
+
 // Set sets the point´s underlying value.
 func (t *tUint16) Set(v uint16) error {
 	t.data = v
@@ -364,6 +550,32 @@ func (t *tUint16) Factor() int16 { return t.factor(t) }
 // Value returns the scaled value as defined by the specification.
 func (t *tUint16) Value() float64 { return float64(t.Get()) * math.Pow10(int(t.Factor())) }
 
+// Raw returns the point´s underlying value.
+func (t *tUint16) Raw() interface{} { return t.Get() }
+
+// Float returns the point´s numeric value, scaled by its factor.
+func (t *tUint16) Float() (float64, bool) {
+	if !t.Valid() {
+		return 0, false
+	}
+	return scaled(float64(t.Get()), t.Factor()), true
+}
+
+// SetFloat sets the point to the numeric value v, scaled by its factor.
+func (t *tUint16) SetFloat(v float64) error {
+	r, err := unscaled(t, v, t.Factor(), 0, math.MaxUint16-1)
+	if err != nil {
+		return err
+	}
+	return t.Set(uint16(r))
+}
+
+// Text returns the textual representation of the point´s value.
+func (t *tUint16) Text() string { return text(t) }
+
+// SetText sets the point´s value from its textual representation.
+func (t *tUint16) SetText(s string) error { return setText(t, s) }
+
 // ****************************************************************************
 
 // Uint32 represents the sunspec type uint32.
@@ -391,6 +603,9 @@ var _ Uint32 = (*tUint32)(nil)
 // Valid specifies whether the underlying value is implemented by the device.
 func (t *tUint32) Valid() bool { return t.Get() != 0xFFFFFFFF }
 
+// Invalidate sets the point´s value to the unimplemented value of its type.
+func (t *tUint32) Invalidate() { t.data = 0xFFFFFFFF }
+
 // String formats the point´s value as string.
 func (t *tUint32) String() string { return fmt.Sprintf("%v", t.Get()) }
 
@@ -408,6 +623,11 @@ func (t *tUint32) decode(buf []byte) error {
 	return t.Set(binary.BigEndian.Uint32(buf))
 }
 
+// validate checks whether the value in buf complies with the point´s definition.
+func (t *tUint32) validate(buf []byte) error {
+	return t.bound(float64(binary.BigEndian.Uint32(buf)) * math.Pow10(int(t.Factor())))
+}
+
 // Set sets the point´s underlying value.
 func (t *tUint32) Set(v uint32) error {
 	t.data = v
@@ -423,6 +643,32 @@ func (t *tUint32) Factor() int16 { return t.factor(t) }
 // Value returns the scaled value as defined by the specification.
 func (t *tUint32) Value() float64 { return float64(t.Get()) * math.Pow10(int(t.Factor())) }
 
+// Raw returns the point´s underlying value.
+func (t *tUint32) Raw() interface{} { return t.Get() }
+
+// Float returns the point´s numeric value, scaled by its factor.
+func (t *tUint32) Float() (float64, bool) {
+	if !t.Valid() {
+		return 0, false
+	}
+	return scaled(float64(t.Get()), t.Factor()), true
+}
+
+// SetFloat sets the point to the numeric value v, scaled by its factor.
+func (t *tUint32) SetFloat(v float64) error {
+	r, err := unscaled(t, v, t.Factor(), 0, math.MaxUint32-1)
+	if err != nil {
+		return err
+	}
+	return t.Set(uint32(r))
+}
+
+// Text returns the textual representation of the point´s value.
+func (t *tUint32) Text() string { return text(t) }
+
+// SetText sets the point´s value from its textual representation.
+func (t *tUint32) SetText(s string) error { return setText(t, s) }
+
 // ****************************************************************************
 
 // Uint64 represents the sunspec type uint64.
@@ -450,6 +696,9 @@ var _ Uint64 = (*tUint64)(nil)
 // Valid specifies whether the underlying value is implemented by the device.
 func (t *tUint64) Valid() bool { return t.Get() != 0xFFFFFFFFFFFFFFFF }
 
+// Invalidate sets the point´s value to the unimplemented value of its type.
+func (t *tUint64) Invalidate() { t.data = 0xFFFFFFFFFFFFFFFF }
+
 // String formats the point´s value as string.
 func (t *tUint64) String() string { return fmt.Sprintf("%v", t.Get()) }
 
@@ -467,6 +716,11 @@ func (t *tUint64) decode(buf []byte) error {
 	return t.Set(binary.BigEndian.Uint64(buf))
 }
 
+// validate checks whether the value in buf complies with the point´s definition.
+func (t *tUint64) validate(buf []byte) error {
+	return t.bound(float64(binary.BigEndian.Uint64(buf)) * math.Pow10(int(t.Factor())))
+}
+
 // Set sets the point´s underlying value.
 func (t *tUint64) Set(v uint64) error {
 	t.data = v
@@ -482,6 +736,32 @@ func (t *tUint64) Factor() int16 { return t.factor(t) }
 // Value returns the scaled value as defined by the specification.
 func (t *tUint64) Value() float64 { return float64(t.Get()) * math.Pow10(int(t.Factor())) }
 
+// Raw returns the point´s underlying value.
+func (t *tUint64) Raw() interface{} { return t.Get() }
+
+// Float returns the point´s numeric value, scaled by its factor.
+func (t *tUint64) Float() (float64, bool) {
+	if !t.Valid() {
+		return 0, false
+	}
+	return scaled(float64(t.Get()), t.Factor()), true
+}
+
+// SetFloat sets the point to the numeric value v, scaled by its factor.
+func (t *tUint64) SetFloat(v float64) error {
+	r, err := unscaled(t, v, t.Factor(), 0, maxUint64)
+	if err != nil {
+		return err
+	}
+	return t.Set(uint64(r))
+}
+
+// Text returns the textual representation of the point´s value.
+func (t *tUint64) Text() string { return text(t) }
+
+// SetText sets the point´s value from its textual representation.
+func (t *tUint64) SetText(s string) error { return setText(t, s) }
+
 // ****************************************************************************
 
 // Acc16 represents the sunspec type acc16.
@@ -507,6 +787,9 @@ var _ Acc16 = (*tAcc16)(nil)
 // Valid specifies whether the underlying value is implemented by the device.
 func (t *tAcc16) Valid() bool { return t.Get() != 0 }
 
+// Invalidate sets the point´s value to the unimplemented value of its type.
+func (t *tAcc16) Invalidate() { t.data = 0 }
+
 // String formats the point´s value as string.
 func (t *tAcc16) String() string { return fmt.Sprintf("%v", t.Get()) }
 
@@ -536,6 +819,32 @@ func (t *tAcc16) Get() uint16 { return t.data }
 // Factor returns the scale value of the point.
 func (t *tAcc16) Factor() int16 { return t.factor(t) }
 
+// Raw returns the point´s underlying value.
+func (t *tAcc16) Raw() interface{} { return t.Get() }
+
+// Float returns the point´s numeric value, scaled by its factor.
+func (t *tAcc16) Float() (float64, bool) {
+	if !t.Valid() {
+		return 0, false
+	}
+	return scaled(float64(t.Get()), t.Factor()), true
+}
+
+// SetFloat sets the point to the numeric value v, scaled by its factor.
+func (t *tAcc16) SetFloat(v float64) error {
+	r, err := unscaled(t, v, t.Factor(), 0, math.MaxUint16)
+	if err != nil {
+		return err
+	}
+	return t.Set(uint16(r))
+}
+
+// Text returns the textual representation of the point´s value.
+func (t *tAcc16) Text() string { return text(t) }
+
+// SetText sets the point´s value from its textual representation.
+func (t *tAcc16) SetText(s string) error { return setText(t, s) }
+
 // ****************************************************************************
 
 // Acc32 represents the sunspec type acc32.
@@ -561,6 +870,9 @@ var _ (Acc32) = (*tAcc32)(nil)
 // Valid specifies whether the underlying value is implemented by the device.
 func (t *tAcc32) Valid() bool { return t.Get() != 0 }
 
+// Invalidate sets the point´s value to the unimplemented value of its type.
+func (t *tAcc32) Invalidate() { t.data = 0 }
+
 // String formats the point´s value as string.
 func (t *tAcc32) String() string { return fmt.Sprintf("%v", t.Get()) }
 
@@ -590,6 +902,32 @@ func (t *tAcc32) Get() uint32 { return t.data }
 // Factor returns the scale value of the point.
 func (t *tAcc32) Factor() int16 { return t.factor(t) }
 
+// Raw returns the point´s underlying value.
+func (t *tAcc32) Raw() interface{} { return t.Get() }
+
+// Float returns the point´s numeric value, scaled by its factor.
+func (t *tAcc32) Float() (float64, bool) {
+	if !t.Valid() {
+		return 0, false
+	}
+	return scaled(float64(t.Get()), t.Factor()), true
+}
+
+// SetFloat sets the point to the numeric value v, scaled by its factor.
+func (t *tAcc32) SetFloat(v float64) error {
+	r, err := unscaled(t, v, t.Factor(), 0, math.MaxUint32)
+	if err != nil {
+		return err
+	}
+	return t.Set(uint32(r))
+}
+
+// Text returns the textual representation of the point´s value.
+func (t *tAcc32) Text() string { return text(t) }
+
+// SetText sets the point´s value from its textual representation.
+func (t *tAcc32) SetText(s string) error { return setText(t, s) }
+
 // ****************************************************************************
 
 // Acc64 represents the sunspec type acc64.
@@ -615,6 +953,9 @@ var _ Acc64 = (*tAcc64)(nil)
 // Valid specifies whether the underlying value is implemented by the device.
 func (t *tAcc64) Valid() bool { return t.Get() != 0 }
 
+// Invalidate sets the point´s value to the unimplemented value of its type.
+func (t *tAcc64) Invalidate() { t.data = 0 }
+
 // String formats the point´s value as string.
 func (t *tAcc64) String() string { return fmt.Sprintf("%v", t.Get()) }
 
@@ -644,6 +985,32 @@ func (t *tAcc64) Get() uint64 { return t.data }
 // Factor returns the scale value of the point.
 func (t *tAcc64) Factor() int16 { return t.factor(t) }
 
+// Raw returns the point´s underlying value.
+func (t *tAcc64) Raw() interface{} { return t.Get() }
+
+// Float returns the point´s numeric value, scaled by its factor.
+func (t *tAcc64) Float() (float64, bool) {
+	if !t.Valid() {
+		return 0, false
+	}
+	return scaled(float64(t.Get()), t.Factor()), true
+}
+
+// SetFloat sets the point to the numeric value v, scaled by its factor.
+func (t *tAcc64) SetFloat(v float64) error {
+	r, err := unscaled(t, v, t.Factor(), 0, maxUint64)
+	if err != nil {
+		return err
+	}
+	return t.Set(uint64(r))
+}
+
+// Text returns the textual representation of the point´s value.
+func (t *tAcc64) Text() string { return text(t) }
+
+// SetText sets the point´s value from its textual representation.
+func (t *tAcc64) SetText(s string) error { return setText(t, s) }
+
 // ****************************************************************************
 
 // Count represents the sunspec type count.
@@ -664,6 +1031,9 @@ var _ Count = (*tCount)(nil)
 // Valid specifies whether the underlying value is implemented by the device.
 func (t *tCount) Valid() bool { return t.Get() != 0 }
 
+// Invalidate sets the point´s value to the unimplemented value of its type.
+func (t *tCount) Invalidate() { t.data = 0 }
+
 // String formats the point´s value as string.
 func (t *tCount) String() string { return fmt.Sprintf("%v", t.Get()) }
 
@@ -690,6 +1060,32 @@ func (t *tCount) set(v uint16) error {
 // Get returns the point´s underlying value.
 func (t *tCount) Get() uint16 { return t.data }
 
+// Raw returns the point´s underlying value.
+func (t *tCount) Raw() interface{} { return t.Get() }
+
+// Float returns the point´s numeric value.
+func (t *tCount) Float() (float64, bool) {
+	if !t.Valid() {
+		return 0, false
+	}
+	return float64(t.Get()), true
+}
+
+// SetFloat sets the point to the numeric value v.
+func (t *tCount) SetFloat(v float64) error {
+	r, err := unscaled(t, v, 0, 0, math.MaxUint16)
+	if err != nil {
+		return err
+	}
+	return t.set(uint16(r))
+}
+
+// Text returns the textual representation of the point´s value.
+func (t *tCount) Text() string { return text(t) }
+
+// SetText sets the point´s value from its textual representation.
+func (t *tCount) SetText(s string) error { return setText(t, s) }
+
 // ****************************************************************************
 
 // Bitfield16 represents the sunspec type bitfield16.
@@ -705,7 +1101,14 @@ type Bitfield16 interface {
 	// Field returns the individual bit values as bool array.
 	Field() [16]bool
 	// States returns all active enumerated states, correlating the bit value to its symbol.
+	// Bits without a defined symbol are named "unknown(n)", n being the bit position.
 	States() []string
+	// SetStates sets exactly the bits of the named states, clearing all others.
+	SetStates(names ...string) error
+	// HasState specifies whether the bit of the named state is set.
+	HasState(name string) bool
+	// Symbols returns the enumerated states of the point.
+	Symbols() Symbols
 }
 
 type tBitfield16 struct {
@@ -719,6 +1122,9 @@ var _ Bitfield16 = (*tBitfield16)(nil)
 // Valid specifies whether the underlying value is implemented by the device.
 func (t *tBitfield16) Valid() bool { return t.Get() != 0xFFFF }
 
+// Invalidate sets the point´s value to the unimplemented value of its type.
+func (t *tBitfield16) Invalidate() { t.data = 0xFFFF }
+
 // String formats the point´s value as string.
 func (t *tBitfield16) String() string { return fmt.Sprintf("%v", t.Get()) }
 
@@ -736,6 +1142,11 @@ func (t *tBitfield16) decode(buf []byte) error {
 	return t.Set(binary.BigEndian.Uint16(buf))
 }
 
+// validate checks whether the value in buf complies with the point´s definition.
+func (t *tBitfield16) validate(buf []byte) error {
+	return t.symbols.bits(t, uint64(binary.BigEndian.Uint16(buf)))
+}
+
 // Set sets the point´s underlying value.
 func (t *tBitfield16) Set(v uint16) error {
 	t.data = v
@@ -749,7 +1160,7 @@ func (t *tBitfield16) Get() uint16 { return t.data }
 func (t *tBitfield16) Flip(pos int, v bool) error {
 	switch {
 	case pos < 0 || pos > 15:
-		return errors.New("sunspec: out of bounds bit position ")
+		return ErrOutOfRange
 	case v:
 		return t.Set(t.Get() | (1 << pos))
 	}
@@ -771,12 +1182,87 @@ func (t *tBitfield16) States() (s []string) {
 	}
 	for i, v := range t.Field() {
 		if v {
-			s = append(s, t.symbols[uint32(i)].Name())
+			s = append(s, t.symbols.name(uint32(i)))
 		}
 	}
 	return s
 }
 
+// SetStates sets exactly the bits of the named states, clearing all others.
+func (t *tBitfield16) SetStates(names ...string) error {
+	m, err := t.symbols.mask(t, 16, names)
+	if err != nil {
+		return err
+	}
+	return t.Set(uint16(m))
+}
+
+// HasState specifies whether the bit of the named state is set.
+func (t *tBitfield16) HasState(name string) bool {
+	m, err := t.symbols.mask(t, 16, []string{name})
+	return err == nil && t.Valid() && uint64(t.Get())&m != 0
+}
+
+// Symbols returns the enumerated states of the point.
+func (t *tBitfield16) Symbols() Symbols { return t.symbols }
+
+// Raw returns the point´s underlying value.
+func (t *tBitfield16) Raw() interface{} { return t.Get() }
+
+// Float returns the point´s numeric value.
+func (t *tBitfield16) Float() (float64, bool) {
+	if !t.Valid() {
+		return 0, false
+	}
+	return float64(t.Get()), true
+}
+
+// SetFloat sets the point to the numeric value v.
+func (t *tBitfield16) SetFloat(v float64) error {
+	r, err := unscaled(t, v, 0, 0, math.MaxUint16-1)
+	if err != nil {
+		return err
+	}
+	return t.Set(uint16(r))
+}
+
+// Text returns the textual representation of the point´s value.
+func (t *tBitfield16) Text() string {
+	if !t.Valid() {
+		return ""
+	}
+	var names []string
+	for i, v := range t.Field() {
+		sym, ok := t.symbols[uint32(i)]
+		switch {
+		case !v:
+		case !ok || sym == nil:
+			// bits without a symbol are not representable by their names
+			return strconv.FormatUint(uint64(t.Get()), 10)
+		default:
+			names = append(names, sym.Name())
+		}
+	}
+	if len(names) == 0 {
+		return "0"
+	}
+	return strings.Join(names, ",")
+}
+
+// SetText sets the point´s value from its textual representation.
+func (t *tBitfield16) SetText(s string) error {
+	if v, err := strconv.ParseUint(strings.TrimSpace(s), 0, 16); err == nil {
+		return t.Set(uint16(v))
+	} else if s == "" {
+		return setText(t, s)
+	}
+	names := strings.Split(s, ",")
+	for i := range names {
+		names[i] = strings.TrimSpace(names[i])
+	}
+	return t.SetStates(names...)
+}
+
 // ****************************************************************************
 
 // Bitfield32 represents the sunspec type bitfield32.
@@ -792,7 +1278,14 @@ type Bitfield32 interface {
 	// Field returns the individual bit values as bool array.
 	Field() [32]bool
 	// States returns all active enumerated states, correlating the bit value to its symbol.
+	// Bits without a defined symbol are named "unknown(n)", n being the bit position.
 	States() []string
+	// SetStates sets exactly the bits of the named states, clearing all others.
+	SetStates(names ...string) error
+	// HasState specifies whether the bit of the named state is set.
+	HasState(name string) bool
+	// Symbols returns the enumerated states of the point.
+	Symbols() Symbols
 }
 
 type tBitfield32 struct {
@@ -806,6 +1299,9 @@ var _ Bitfield32 = (*tBitfield32)(nil)
 // Valid specifies whether the underlying value is implemented by the device.
 func (t *tBitfield32) Valid() bool { return t.Get() != 0xFFFFFFFF }
 
+// Invalidate sets the point´s value to the unimplemented value of its type.
+func (t *tBitfield32) Invalidate() { t.data = 0xFFFFFFFF }
+
 // String formats the point´s value as string.
 func (t *tBitfield32) String() string { return fmt.Sprintf("%v", t.Get()) }
 
@@ -823,6 +1319,11 @@ func (t *tBitfield32) decode(buf []byte) error {
 	return t.Set(binary.BigEndian.Uint32(buf))
 }
 
+// validate checks whether the value in buf complies with the point´s definition.
+func (t *tBitfield32) validate(buf []byte) error {
+	return t.symbols.bits(t, uint64(binary.BigEndian.Uint32(buf)))
+}
+
 // Set sets the point´s underlying value.
 func (t *tBitfield32) Set(v uint32) error {
 	t.data = v
@@ -836,7 +1337,7 @@ func (t *tBitfield32) Get() uint32 { return t.data }
 func (t *tBitfield32) Flip(pos int, v bool) error {
 	switch {
 	case pos < 0 || pos > 31:
-		return errors.New("sunspec: out of bounds bit position ")
+		return ErrOutOfRange
 	case v:
 		return t.Set(t.Get() | (1 << pos))
 	}
@@ -858,12 +1359,87 @@ func (t *tBitfield32) States() (s []string) {
 	}
 	for i, v := range t.Field() {
 		if v {
-			s = append(s, t.symbols[uint32(i)].Name())
+			s = append(s, t.symbols.name(uint32(i)))
 		}
 	}
 	return s
 }
 
+// SetStates sets exactly the bits of the named states, clearing all others.
+func (t *tBitfield32) SetStates(names ...string) error {
+	m, err := t.symbols.mask(t, 32, names)
+	if err != nil {
+		return err
+	}
+	return t.Set(uint32(m))
+}
+
+// HasState specifies whether the bit of the named state is set.
+func (t *tBitfield32) HasState(name string) bool {
+	m, err := t.symbols.mask(t, 32, []string{name})
+	return err == nil && t.Valid() && uint64(t.Get())&m != 0
+}
+
+// Symbols returns the enumerated states of the point.
+func (t *tBitfield32) Symbols() Symbols { return t.symbols }
+
+// Raw returns the point´s underlying value.
+func (t *tBitfield32) Raw() interface{} { return t.Get() }
+
+// Float returns the point´s numeric value.
+func (t *tBitfield32) Float() (float64, bool) {
+	if !t.Valid() {
+		return 0, false
+	}
+	return float64(t.Get()), true
+}
+
+// SetFloat sets the point to the numeric value v.
+func (t *tBitfield32) SetFloat(v float64) error {
+	r, err := unscaled(t, v, 0, 0, math.MaxUint32-1)
+	if err != nil {
+		return err
+	}
+	return t.Set(uint32(r))
+}
+
+// Text returns the textual representation of the point´s value.
+func (t *tBitfield32) Text() string {
+	if !t.Valid() {
+		return ""
+	}
+	var names []string
+	for i, v := range t.Field() {
+		sym, ok := t.symbols[uint32(i)]
+		switch {
+		case !v:
+		case !ok || sym == nil:
+			// bits without a symbol are not representable by their names
+			return strconv.FormatUint(uint64(t.Get()), 10)
+		default:
+			names = append(names, sym.Name())
+		}
+	}
+	if len(names) == 0 {
+		return "0"
+	}
+	return strings.Join(names, ",")
+}
+
+// SetText sets the point´s value from its textual representation.
+func (t *tBitfield32) SetText(s string) error {
+	if v, err := strconv.ParseUint(strings.TrimSpace(s), 0, 32); err == nil {
+		return t.Set(uint32(v))
+	} else if s == "" {
+		return setText(t, s)
+	}
+	names := strings.Split(s, ",")
+	for i := range names {
+		names[i] = strings.TrimSpace(names[i])
+	}
+	return t.SetStates(names...)
+}
+
 // ****************************************************************************
 
 // Bitfield64 represents the sunspec type bitfield64.
@@ -879,7 +1455,14 @@ type Bitfield64 interface {
 	// Field returns the individual bit values as bool array.
 	Field() [64]bool
 	// States returns all active enumerated states, correlating the bit value to its symbol.
+	// Bits without a defined symbol are named "unknown(n)", n being the bit position.
 	States() []string
+	// SetStates sets exactly the bits of the named states, clearing all others.
+	SetStates(names ...string) error
+	// HasState specifies whether the bit of the named state is set.
+	HasState(name string) bool
+	// Symbols returns the enumerated states of the point.
+	Symbols() Symbols
 }
 
 type tBitfield64 struct {
@@ -893,6 +1476,9 @@ var _ Bitfield64 = (*tBitfield64)(nil)
 // Valid specifies whether the underlying value is implemented by the device.
 func (t *tBitfield64) Valid() bool { return t.Get() != 0xFFFFFFFFFFFFFFFF }
 
+// Invalidate sets the point´s value to the unimplemented value of its type.
+func (t *tBitfield64) Invalidate() { t.data = 0xFFFFFFFFFFFFFFFF }
+
 // String formats the point´s value as string.
 func (t *tBitfield64) String() string { return fmt.Sprintf("%v", t.Get()) }
 
@@ -910,6 +1496,11 @@ func (t *tBitfield64) decode(buf []byte) error {
 	return t.Set(binary.BigEndian.Uint64(buf))
 }
 
+// validate checks whether the value in buf complies with the point´s definition.
+func (t *tBitfield64) validate(buf []byte) error {
+	return t.symbols.bits(t, binary.BigEndian.Uint64(buf))
+}
+
 // Set sets the point´s underlying value.
 func (t *tBitfield64) Set(v uint64) error {
 	t.data = v
@@ -923,7 +1514,7 @@ func (t *tBitfield64) Get() uint64 { return t.data }
 func (t *tBitfield64) Flip(pos int, v bool) error {
 	switch {
 	case pos < 0 || pos > 63:
-		return errors.New("sunspec: out of bounds bit position ")
+		return ErrOutOfRange
 	case v:
 		return t.Set(t.Get() | (1 << pos))
 	}
@@ -945,12 +1536,87 @@ func (t *tBitfield64) States() (s []string) {
 	}
 	for i, v := range t.Field() {
 		if v {
-			s = append(s, t.symbols[uint32(i)].Name())
+			s = append(s, t.symbols.name(uint32(i)))
 		}
 	}
 	return s
 }
 
+// SetStates sets exactly the bits of the named states, clearing all others.
+func (t *tBitfield64) SetStates(names ...string) error {
+	m, err := t.symbols.mask(t, 64, names)
+	if err != nil {
+		return err
+	}
+	return t.Set(m)
+}
+
+// HasState specifies whether the bit of the named state is set.
+func (t *tBitfield64) HasState(name string) bool {
+	m, err := t.symbols.mask(t, 64, []string{name})
+	return err == nil && t.Valid() && uint64(t.Get())&m != 0
+}
+
+// Symbols returns the enumerated states of the point.
+func (t *tBitfield64) Symbols() Symbols { return t.symbols }
+
+// Raw returns the point´s underlying value.
+func (t *tBitfield64) Raw() interface{} { return t.Get() }
+
+// Float returns the point´s numeric value.
+func (t *tBitfield64) Float() (float64, bool) {
+	if !t.Valid() {
+		return 0, false
+	}
+	return float64(t.Get()), true
+}
+
+// SetFloat sets the point to the numeric value v.
+func (t *tBitfield64) SetFloat(v float64) error {
+	r, err := unscaled(t, v, 0, 0, maxUint64)
+	if err != nil {
+		return err
+	}
+	return t.Set(uint64(r))
+}
+
+// Text returns the textual representation of the point´s value.
+func (t *tBitfield64) Text() string {
+	if !t.Valid() {
+		return ""
+	}
+	var names []string
+	for i, v := range t.Field() {
+		sym, ok := t.symbols[uint32(i)]
+		switch {
+		case !v:
+		case !ok || sym == nil:
+			// bits without a symbol are not representable by their names
+			return strconv.FormatUint(uint64(t.Get()), 10)
+		default:
+			names = append(names, sym.Name())
+		}
+	}
+	if len(names) == 0 {
+		return "0"
+	}
+	return strings.Join(names, ",")
+}
+
+// SetText sets the point´s value from its textual representation.
+func (t *tBitfield64) SetText(s string) error {
+	if v, err := strconv.ParseUint(strings.TrimSpace(s), 0, 64); err == nil {
+		return t.Set(v)
+	} else if s == "" {
+		return setText(t, s)
+	}
+	names := strings.Split(s, ",")
+	for i := range names {
+		names[i] = strings.TrimSpace(names[i])
+	}
+	return t.SetStates(names...)
+}
+
 // ****************************************************************************
 
 // Enum16 represents the sunspec type enum16.
@@ -962,7 +1628,12 @@ type Enum16 interface {
 	// Get returns the point´s underlying value.
 	Get() uint16
 	// State returns the currently active enumerated state.
+	// Values without a defined symbol are named "unknown(n)", n being the value.
 	State() string
+	// SetState sets the point´s value to the named state.
+	SetState(name string) error
+	// Symbols returns the enumerated states of the point.
+	Symbols() Symbols
 }
 
 type tEnum16 struct {
@@ -976,6 +1647,9 @@ var _ Enum16 = (*tEnum16)(nil)
 // Valid specifies whether the underlying value is implemented by the device.
 func (t *tEnum16) Valid() bool { return t.Get() != 0xFFFF }
 
+// Invalidate sets the point´s value to the unimplemented value of its type.
+func (t *tEnum16) Invalidate() { t.data = 0xFFFF }
+
 // String formats the point´s value as string.
 func (t *tEnum16) String() string { return fmt.Sprintf("%v", t.Get()) }
 
@@ -993,6 +1667,11 @@ func (t *tEnum16) decode(buf []byte) error {
 	return t.Set(binary.BigEndian.Uint16(buf))
 }
 
+// validate checks whether the value in buf complies with the point´s definition.
+func (t *tEnum16) validate(buf []byte) error {
+	return t.symbols.defined(t, uint32(binary.BigEndian.Uint16(buf)))
+}
+
 // Set sets the point´s underlying value.
 func (t *tEnum16) Set(v uint16) error {
 	t.data = v
@@ -1003,7 +1682,61 @@ func (t *tEnum16) Set(v uint16) error {
 func (t *tEnum16) Get() uint16 { return t.data }
 
 // State returns the currently active enumerated state.
-func (t *tEnum16) State() string { return t.symbols[uint32(t.Get())].Name() }
+func (t *tEnum16) State() string { return t.symbols.name(uint32(t.Get())) }
+
+// SetState sets the point´s value to the named state.
+func (t *tEnum16) SetState(name string) error {
+	v, err := t.symbols.value(t, name)
+	switch {
+	case err != nil:
+		return err
+	case v > math.MaxUint16:
+		return fmt.Errorf("%w: symbol %q exceeds %q", ErrOutOfRange, name, t.Name())
+	}
+	return t.Set(uint16(v))
+}
+
+// Symbols returns the enumerated states of the point.
+func (t *tEnum16) Symbols() Symbols { return t.symbols }
+
+// Raw returns the point´s underlying value.
+func (t *tEnum16) Raw() interface{} { return t.Get() }
+
+// Float returns the point´s numeric value.
+func (t *tEnum16) Float() (float64, bool) {
+	if !t.Valid() {
+		return 0, false
+	}
+	return float64(t.Get()), true
+}
+
+// SetFloat sets the point to the numeric value v.
+func (t *tEnum16) SetFloat(v float64) error {
+	r, err := unscaled(t, v, 0, 0, math.MaxUint16-1)
+	if err != nil {
+		return err
+	}
+	return t.Set(uint16(r))
+}
+
+// Text returns the textual representation of the point´s value.
+func (t *tEnum16) Text() string {
+	if !t.Valid() {
+		return ""
+	}
+	if sym, ok := t.symbols[uint32(t.Get())]; ok && sym != nil {
+		return sym.Name()
+	}
+	return strconv.FormatUint(uint64(t.Get()), 10)
+}
+
+// SetText sets the point´s value from its textual representation.
+func (t *tEnum16) SetText(s string) error {
+	if _, err := strconv.ParseFloat(s, 64); err == nil || s == "" {
+		return setText(t, s)
+	}
+	return t.SetState(strings.TrimSpace(s))
+}
 
 // ****************************************************************************
 
@@ -1016,7 +1749,12 @@ type Enum32 interface {
 	// Get returns the point´s underlying value.
 	Get() uint32
 	// State returns the currently active enumerated state.
+	// Values without a defined symbol are named "unknown(n)", n being the value.
 	State() string
+	// SetState sets the point´s value to the named state.
+	SetState(name string) error
+	// Symbols returns the enumerated states of the point.
+	Symbols() Symbols
 }
 
 type tEnum32 struct {
@@ -1030,6 +1768,9 @@ var _ Enum32 = (*tEnum32)(nil)
 // Valid specifies whether the underlying value is implemented by the device.
 func (t *tEnum32) Valid() bool { return t.Get() != 0xFFFFFFFF }
 
+// Invalidate sets the point´s value to the unimplemented value of its type.
+func (t *tEnum32) Invalidate() { t.data = 0xFFFFFFFF }
+
 // String formats the point´s value as string.
 func (t *tEnum32) String() string { return fmt.Sprintf("%v", t.Get()) }
 
@@ -1047,6 +1788,11 @@ func (t *tEnum32) decode(buf []byte) error {
 	return t.Set(binary.BigEndian.Uint32(buf))
 }
 
+// validate checks whether the value in buf complies with the point´s definition.
+func (t *tEnum32) validate(buf []byte) error {
+	return t.symbols.defined(t, binary.BigEndian.Uint32(buf))
+}
+
 // Set sets the point´s underlying value.
 func (t *tEnum32) Set(v uint32) error {
 	t.data = v
@@ -1057,17 +1803,73 @@ func (t *tEnum32) Set(v uint32) error {
 func (t *tEnum32) Get() uint32 { return t.data }
 
 // State returns the currently active enumerated state.
-func (t *tEnum32) State() string { return t.symbols[t.Get()].Name() }
+func (t *tEnum32) State() string { return t.symbols.name(t.Get()) }
+
+// SetState sets the point´s value to the named state.
+func (t *tEnum32) SetState(name string) error {
+	v, err := t.symbols.value(t, name)
+	if err != nil {
+		return err
+	}
+	return t.Set(v)
+}
+
+// Symbols returns the enumerated states of the point.
+func (t *tEnum32) Symbols() Symbols { return t.symbols }
+
+// Raw returns the point´s underlying value.
+func (t *tEnum32) Raw() interface{} { return t.Get() }
+
+// Float returns the point´s numeric value.
+func (t *tEnum32) Float() (float64, bool) {
+	if !t.Valid() {
+		return 0, false
+	}
+	return float64(t.Get()), true
+}
+
+// SetFloat sets the point to the numeric value v.
+func (t *tEnum32) SetFloat(v float64) error {
+	r, err := unscaled(t, v, 0, 0, math.MaxUint32-1)
+	if err != nil {
+		return err
+	}
+	return t.Set(uint32(r))
+}
+
+// Text returns the textual representation of the point´s value.
+func (t *tEnum32) Text() string {
+	if !t.Valid() {
+		return ""
+	}
+	if sym, ok := t.symbols[t.Get()]; ok && sym != nil {
+		return sym.Name()
+	}
+	return strconv.FormatUint(uint64(t.Get()), 10)
+}
+
+// SetText sets the point´s value from its textual representation.
+func (t *tEnum32) SetText(s string) error {
+	if _, err := strconv.ParseFloat(s, 64); err == nil || s == "" {
+		return setText(t, s)
+	}
+	return t.SetState(strings.TrimSpace(s))
+}
 
 // ****************************************************************************
 
 // String represents the sunspec type string.
+// The value is stored in a fixed number of registers, shorter values are padded with NUL bytes.
+// Strings are encoded as UTF-8, ASCII being a subset of it, hence the capacity of the point
+// is measured in bytes rather than characters.
 type String interface {
 	// Point defines the generic behavior all sunspec types have in common.
 	Point
 	// Set sets the point´s underlying value.
+	// An error is returned if the value exceeds the capacity of the point,
+	// is not valid UTF-8 or contains NUL bytes.
 	Set(v string) error
-	// Get returns the point´s underlying value.
+	// Get returns the point´s underlying value without its NUL padding.
 	Get() string
 }
 
@@ -1079,34 +1881,82 @@ type tString struct {
 var _ String = (*tString)(nil)
 
 // Valid specifies whether the underlying value is implemented by the device.
+// Unimplemented strings are filled with NUL bytes, hence their value is empty.
 func (t *tString) Valid() bool { return t.Get() != "" }
 
+// Invalidate sets the point´s value to the unimplemented value of its type.
+func (t *tString) Invalidate() {
+	t.data = t.data[:cap(t.data)]
+	for i := range t.data {
+		t.data[i] = 0
+	}
+}
+
 // String formats the point´s value as string.
 func (t *tString) String() string { return t.Get() }
 
 // Quantity returns the number of modbus registers required to store the underlying value.
 func (t *tString) Quantity() uint16 { return uint16(cap(t.data) / 2) }
 
-// encode puts the point´s value into a buffer.
+// encode puts the point´s value including its padding into a buffer.
 func (t *tString) encode(buf []byte) error {
-	copy(buf, []byte(t.Get()))
+	copy(buf, t.data[:cap(t.data)])
 	return nil
 }
 
 // decode sets the point´s value from a buffer.
+// The data is taken as is, as devices are not necessarily compliant regarding the encoding.
 func (t *tString) decode(buf []byte) error {
-	return t.Set(string(buf[:2*t.Quantity()]))
+	t.data = t.data[:cap(t.data)]
+	copy(t.data, buf[:2*t.Quantity()])
+	return nil
 }
 
 // Set sets the point´s underlying value.
 func (t *tString) Set(v string) error {
+	switch {
+	case len(v) > cap(t.data):
+		return fmt.Errorf("%w: string of %v bytes exceeds the capacity of %v bytes", ErrOutOfRange, len(v), cap(t.data))
+	case !utf8.ValidString(v):
+		return fmt.Errorf("%w: string is not valid utf-8", ErrIllegalValue)
+	case strings.IndexByte(v, 0) >= 0:
+		return fmt.Errorf("%w: string contains a NUL byte", ErrIllegalValue)
+	}
 	t.data = t.data[:cap(t.data)]
-	copy(t.data, v)
+	// the remainder is padded, clearing a previous longer value
+	for i := copy(t.data, v); i < len(t.data); i++ {
+		t.data[i] = 0
+	}
 	return nil
 }
 
-// Get returns the point´s underlying value.
-func (t *tString) Get() string { return string(t.data) }
+// Get returns the point´s underlying value, which is terminated by the first NUL byte.
+func (t *tString) Get() string {
+	b := t.data[:cap(t.data)]
+	if i := bytes.IndexByte(b, 0); i >= 0 {
+		b = b[:i]
+	}
+	return string(b)
+}
+
+// Raw returns the point´s underlying value.
+func (t *tString) Raw() interface{} { return t.Get() }
+
+// Float returns the point´s numeric value.
+func (t *tString) Float() (float64, bool) {
+	return 0, false
+}
+
+// SetFloat sets the point to the numeric value v.
+func (t *tString) SetFloat(v float64) error {
+	return nonNumeric(t)
+}
+
+// Text returns the textual representation of the point´s value.
+func (t *tString) Text() string { return t.Get() }
+
+// SetText sets the point´s value from its textual representation.
+func (t *tString) SetText(s string) error { return t.Set(s) }
 
 // ****************************************************************************
 
@@ -1128,7 +1978,10 @@ type tFloat32 struct {
 var _ Float32 = (*tFloat32)(nil)
 
 // Valid specifies whether the underlying value is implemented by the device.
-func (t *tFloat32) Valid() bool { return t.Get() != 0x7FC00000 }
+func (t *tFloat32) Valid() bool { return !math.IsNaN(float64(t.Get())) }
+
+// Invalidate sets the point´s value to the unimplemented value of its type.
+func (t *tFloat32) Invalidate() { t.data = float32(math.NaN()) }
 
 // String formats the point´s value as string.
 func (t *tFloat32) String() string { return fmt.Sprintf("%v", t.Get()) }
@@ -1147,6 +2000,11 @@ func (t *tFloat32) decode(buf []byte) error {
 	return t.Set(math.Float32frombits(binary.BigEndian.Uint32(buf)))
 }
 
+// validate checks whether the value in buf complies with the point´s definition.
+func (t *tFloat32) validate(buf []byte) error {
+	return t.bound(float64(math.Float32frombits(binary.BigEndian.Uint32(buf))))
+}
+
 // Set sets the point´s underlying value.
 func (t *tFloat32) Set(v float32) error {
 	t.data = v
@@ -1156,6 +2014,33 @@ func (t *tFloat32) Set(v float32) error {
 // Get returns the point´s underlying value.
 func (t *tFloat32) Get() float32 { return t.data }
 
+// Raw returns the point´s underlying value.
+func (t *tFloat32) Raw() interface{} { return t.Get() }
+
+// Float returns the point´s numeric value.
+func (t *tFloat32) Float() (float64, bool) {
+	return float64(t.Get()), t.Valid()
+}
+
+// SetFloat sets the point to the numeric value v.
+func (t *tFloat32) SetFloat(v float64) error {
+	if math.Abs(v) > math.MaxFloat32 && !math.IsInf(v, 0) {
+		return fmt.Errorf("%w: %v for point %q", ErrOutOfRange, v, t.Name())
+	}
+	return t.Set(float32(v))
+}
+
+// Text returns the textual representation of the point´s value.
+func (t *tFloat32) Text() string {
+	if !t.Valid() {
+		return ""
+	}
+	return strconv.FormatFloat(float64(t.Get()), 'f', -1, 32)
+}
+
+// SetText sets the point´s value from its textual representation.
+func (t *tFloat32) SetText(s string) error { return setText(t, s) }
+
 // ****************************************************************************
 
 // Float64 represents the sunspec type float64.
@@ -1176,7 +2061,10 @@ type tFloat64 struct {
 var _ Float64 = (*tFloat64)(nil)
 
 // Valid specifies whether the underlying value is implemented by the device.
-func (t *tFloat64) Valid() bool { return t.Get() != 0x7FF8000000000000 }
+func (t *tFloat64) Valid() bool { return !math.IsNaN(t.Get()) }
+
+// Invalidate sets the point´s value to the unimplemented value of its type.
+func (t *tFloat64) Invalidate() { t.data = math.NaN() }
 
 // String formats the point´s value as string.
 func (t *tFloat64) String() string { return fmt.Sprintf("%v", t.Get()) }
@@ -1195,6 +2083,11 @@ func (t *tFloat64) decode(buf []byte) error {
 	return t.Set(math.Float64frombits(binary.BigEndian.Uint64(buf)))
 }
 
+// validate checks whether the value in buf complies with the point´s definition.
+func (t *tFloat64) validate(buf []byte) error {
+	return t.bound(math.Float64frombits(binary.BigEndian.Uint64(buf)))
+}
+
 // Set sets the point´s underlying value.
 func (t *tFloat64) Set(v float64) error {
 	t.data = v
@@ -1204,6 +2097,25 @@ func (t *tFloat64) Set(v float64) error {
 // Get returns the point´s underlying value.
 func (t *tFloat64) Get() float64 { return t.data }
 
+// Raw returns the point´s underlying value.
+func (t *tFloat64) Raw() interface{} { return t.Get() }
+
+// Float returns the point´s numeric value.
+func (t *tFloat64) Float() (float64, bool) {
+	return t.Get(), t.Valid()
+}
+
+// SetFloat sets the point to the numeric value v.
+func (t *tFloat64) SetFloat(v float64) error {
+	return t.Set(v)
+}
+
+// Text returns the textual representation of the point´s value.
+func (t *tFloat64) Text() string { return text(t) }
+
+// SetText sets the point´s value from its textual representation.
+func (t *tFloat64) SetText(s string) error { return setText(t, s) }
+
 // ****************************************************************************
 
 // Ipaddr represents the sunspec type ipaddr.
@@ -1214,8 +2126,8 @@ type Ipaddr interface {
 	Set(v net.IP) error
 	// Get returns the point´s underlying value.
 	Get() net.IP
-	// Raw returns the point´s raw data.
-	Raw() [4]byte
+	// Bytes returns the point´s raw data.
+	Bytes() [4]byte
 }
 
 type tIpaddr struct {
@@ -1228,6 +2140,9 @@ var _ Ipaddr = (*tIpaddr)(nil)
 // Valid specifies whether the underlying value is implemented by the device.
 func (t *tIpaddr) Valid() bool { return t.data != [4]byte{} }
 
+// Invalidate sets the point´s value to the unimplemented value of its type.
+func (t *tIpaddr) Invalidate() { t.data = [4]byte{} }
+
 // String formats the point´s value as string.
 func (t *tIpaddr) String() string { return fmt.Sprintf("%v", t.Get()) }
 
@@ -1236,33 +2151,71 @@ func (t *tIpaddr) Quantity() uint16 { return uint16(len(t.data) / 2) }
 
 // encode puts the point´s value into a buffer.
 func (t *tIpaddr) encode(buf []byte) error {
-	copy(buf, t.Get())
+	copy(buf, t.data[:])
 	return nil
 }
 
 // decode sets the point´s value from a buffer.
 func (t *tIpaddr) decode(buf []byte) error {
-	return t.Set(buf)
+	copy(t.data[:], buf)
+	return nil
 }
 
 // Set sets the point´s underlying value.
+// Only IPv4 addresses are accepted, a nil address marks the point as not implemented.
 func (t *tIpaddr) Set(v net.IP) error {
-	copy(t.data[:len(t.data)], v)
+	ip := v.To4()
+	if ip == nil && v != nil {
+		return fmt.Errorf("%w: %v is not an IPv4 address", ErrIllegalValue, v)
+	}
+	t.data = [4]byte{}
+	copy(t.data[:], ip)
 	return nil
 }
 
 // Get returns the point´s underlying value.
 func (t *tIpaddr) Get() net.IP { return append(net.IP(nil), t.data[:]...) }
 
-// Raw returns the point´s raw data.
-func (t *tIpaddr) Raw() (r [4]byte) {
-	copy(r[:], t.Get())
-	return r
+// Bytes returns the point´s raw data.
+func (t *tIpaddr) Bytes() [4]byte { return t.data }
+
+// Raw returns the point´s underlying value.
+func (t *tIpaddr) Raw() interface{} { return t.data }
+
+// Float returns the point´s numeric value.
+func (t *tIpaddr) Float() (float64, bool) {
+	return 0, false
+}
+
+// SetFloat sets the point to the numeric value v.
+func (t *tIpaddr) SetFloat(v float64) error {
+	return nonNumeric(t)
+}
+
+// Text returns the textual representation of the point´s value.
+func (t *tIpaddr) Text() string {
+	if !t.Valid() {
+		return ""
+	}
+	return t.Get().String()
+}
+
+// SetText sets the point´s value from its textual representation.
+func (t *tIpaddr) SetText(s string) error {
+	if s == "" {
+		t.Invalidate()
+		return nil
+	}
+	ip := net.ParseIP(strings.TrimSpace(s))
+	if ip == nil {
+		return fmt.Errorf("%w: %q is not an IPv4 address for point %q", ErrIllegalValue, s, t.Name())
+	}
+	return t.Set(ip)
 }
 
 // ****************************************************************************
 
-// Ipaddr represents the sunspec type ipaddr.
+// Ipv6addr represents the sunspec type ipv6addr.
 type Ipv6addr interface {
 	// Point defines the generic behavior all sunspec types have in common.
 	Point
@@ -1270,8 +2223,8 @@ type Ipv6addr interface {
 	Set(v net.IP) error
 	// Get returns the point´s underlying value.
 	Get() net.IP
-	// Raw returns the point´s raw data.
-	Raw() [16]byte
+	// Bytes returns the point´s raw data.
+	Bytes() [16]byte
 }
 
 type tIpv6addr struct {
@@ -1284,6 +2237,9 @@ var _ Ipv6addr = (*tIpv6addr)(nil)
 // Valid specifies whether the underlying value is implemented by the device.
 func (t *tIpv6addr) Valid() bool { return t.data != [16]byte{} }
 
+// Invalidate sets the point´s value to the unimplemented value of its type.
+func (t *tIpv6addr) Invalidate() { t.data = [16]byte{} }
+
 // String formats the point´s value as string.
 func (t *tIpv6addr) String() string { return fmt.Sprintf("%v", t.Get()) }
 
@@ -1292,28 +2248,66 @@ func (t *tIpv6addr) Quantity() uint16 { return uint16(len(t.data) / 2) }
 
 // encode puts the point´s value into a buffer.
 func (t *tIpv6addr) encode(buf []byte) error {
-	copy(buf, t.Get())
+	copy(buf, t.data[:])
 	return nil
 }
 
 // decode sets the point´s value from a buffer.
 func (t *tIpv6addr) decode(buf []byte) error {
-	return t.Set(buf)
+	copy(t.data[:], buf)
+	return nil
 }
 
 // Set sets the point´s underlying value.
+// IPv4 addresses are stored in their IPv4-mapped form, a nil address marks the point as not implemented.
 func (t *tIpv6addr) Set(v net.IP) error {
-	copy(t.data[:len(t.data)], v)
+	ip := v.To16()
+	if ip == nil && v != nil {
+		return fmt.Errorf("%w: %v is not an IP address", ErrIllegalValue, v)
+	}
+	t.data = [16]byte{}
+	copy(t.data[:], ip)
 	return nil
 }
 
 // Get returns the point´s underlying value.
 func (t *tIpv6addr) Get() net.IP { return append(net.IP(nil), t.data[:]...) }
 
-// Raw returns the point´s raw data.
-func (t *tIpv6addr) Raw() (r [16]byte) {
-	copy(r[:], t.Get())
-	return r
+// Bytes returns the point´s raw data.
+func (t *tIpv6addr) Bytes() [16]byte { return t.data }
+
+// Raw returns the point´s underlying value.
+func (t *tIpv6addr) Raw() interface{} { return t.data }
+
+// Float returns the point´s numeric value.
+func (t *tIpv6addr) Float() (float64, bool) {
+	return 0, false
+}
+
+// SetFloat sets the point to the numeric value v.
+func (t *tIpv6addr) SetFloat(v float64) error {
+	return nonNumeric(t)
+}
+
+// Text returns the textual representation of the point´s value.
+func (t *tIpv6addr) Text() string {
+	if !t.Valid() {
+		return ""
+	}
+	return t.Get().String()
+}
+
+// SetText sets the point´s value from its textual representation.
+func (t *tIpv6addr) SetText(s string) error {
+	if s == "" {
+		t.Invalidate()
+		return nil
+	}
+	ip := net.ParseIP(strings.TrimSpace(s))
+	if ip == nil {
+		return fmt.Errorf("%w: %q is not an IP address for point %q", ErrIllegalValue, s, t.Name())
+	}
+	return t.Set(ip)
 }
 
 // ****************************************************************************
@@ -1326,8 +2320,8 @@ type Eui48 interface {
 	Set(v net.HardwareAddr) error
 	// Get returns the point´s underlying value.
 	Get() net.HardwareAddr
-	// Raw returns the point´s raw data.
-	Raw() [8]byte
+	// Bytes returns the point´s raw data.
+	Bytes() [8]byte
 }
 
 type tEui48 struct {
@@ -1338,7 +2332,10 @@ type tEui48 struct {
 var _ Eui48 = (*tEui48)(nil)
 
 // Valid specifies whether the underlying value is implemented by the device.
-func (t *tEui48) Valid() bool { return true } //?
+func (t *tEui48) Valid() bool { return t.data != [8]byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF} }
+
+// Invalidate sets the point´s value to the unimplemented value of its type.
+func (t *tEui48) Invalidate() { t.data = [8]byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF} }
 
 // String formats the point´s value as string.
 func (t *tEui48) String() string { return fmt.Sprintf("%v", t.Get()) }
@@ -1348,26 +2345,63 @@ func (t *tEui48) Quantity() uint16 { return uint16(len(t.data) / 2) }
 
 // encode puts the point´s value into a buffer.
 func (t *tEui48) encode(buf []byte) error {
-	copy(buf, t.Get())
+	copy(buf, t.data[:])
 	return nil
 }
 
 // decode sets the point´s value from a buffer.
 func (t *tEui48) decode(buf []byte) error {
-	return t.Set(buf)
+	copy(t.data[:], buf)
+	return nil
 }
 
 // Set sets the point´s underlying value.
+// The 6 bytes of the address occupy the lower 3 of the 4 registers, the upper register is zeroed.
 func (t *tEui48) Set(v net.HardwareAddr) error {
-	copy(t.data[:len(t.data)], v)
+	if len(v) != 6 {
+		return fmt.Errorf("%w: %v is not an EUI-48 address", ErrIllegalValue, v)
+	}
+	t.data = [8]byte{}
+	copy(t.data[2:], v)
 	return nil
 }
 
 // Get returns the point´s underlying value.
-func (t *tEui48) Get() net.HardwareAddr { return append(net.HardwareAddr(nil), t.data[:]...) }
+func (t *tEui48) Get() net.HardwareAddr { return append(net.HardwareAddr(nil), t.data[2:]...) }
+
+// Bytes returns the point´s raw data, including the upper register.
+func (t *tEui48) Bytes() [8]byte { return t.data }
 
-// Raw returns the point´s raw data.
-func (t *tEui48) Raw() (r [8]byte) {
-	copy(r[:], t.Get())
-	return r
+// Raw returns the point´s underlying value.
+func (t *tEui48) Raw() interface{} { return t.data }
+
+// Float returns the point´s numeric value.
+func (t *tEui48) Float() (float64, bool) {
+	return 0, false
+}
+
+// SetFloat sets the point to the numeric value v.
+func (t *tEui48) SetFloat(v float64) error {
+	return nonNumeric(t)
+}
+
+// Text returns the textual representation of the point´s value.
+func (t *tEui48) Text() string {
+	if !t.Valid() {
+		return ""
+	}
+	return t.Get().String()
+}
+
+// SetText sets the point´s value from its textual representation.
+func (t *tEui48) SetText(s string) error {
+	if s == "" {
+		t.Invalidate()
+		return nil
+	}
+	mac, err := net.ParseMAC(strings.TrimSpace(s))
+	if err != nil {
+		return fmt.Errorf("%w: %q is not an EUI-48 address for point %q", ErrIllegalValue, s, t.Name())
+	}
+	return t.Set(mac)
 }
@@ -0,0 +1,83 @@
+package sunspec
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Valuer provides generic access to the value of a point, regardless of its type.
+// It is implemented by all point types, thereby allowing exporters or user interfaces
+// to handle values without asserting the individual type interfaces.
+type Valuer interface {
+	// Raw returns the point´s underlying value in its native type, e.g. int16, string or [4]byte.
+	// Padding returns nil.
+	Raw() interface{}
+	// Float returns the point´s numeric value, scaled by its factor if any.
+	// False is returned for non-numeric types and values not implemented by the device.
+	Float() (float64, bool)
+	// SetFloat sets the point to the numeric value v, given scaled by its factor if any.
+	// Values not representable by the point´s type result in ErrOutOfRange.
+	SetFloat(v float64) error
+	// Text returns the textual representation of the point´s value. Numbers are scaled,
+	// enumerations are given by the name of their state and bitfields by the comma separated
+	// names of their active states. Values not implemented by the device are empty.
+	Text() string
+	// SetText sets the point´s value from its textual representation as returned by Text.
+	// Enumerations and bitfields accept numbers as well, the empty text invalidates the point.
+	SetText(s string) error
+}
+
+// maxInt64 and maxUint64 are the largest float64 values converting to int64 and uint64 without overflow.
+const (
+	maxInt64  = 1<<63 - 1024
+	maxUint64 = 1<<64 - 2048
+)
+
+// scaled returns the value v scaled by the factor f.
+func scaled(v float64, f int16) float64 {
+	if f < 0 {
+		return v / math.Pow10(int(-f))
+	}
+	return v * math.Pow10(int(f))
+}
+
+// unscaled returns the underlying value of the scaled value v, rounded to the nearest integer.
+// Values exceeding min or max result in ErrOutOfRange.
+func unscaled(p Point, v float64, f int16, min, max float64) (float64, error) {
+	r := math.Round(scaled(v, -f))
+	if math.IsNaN(r) || r < min || r > max {
+		return 0, fmt.Errorf("%w: %v for point %q", ErrOutOfRange, v, p.Name())
+	}
+	return r, nil
+}
+
+// decimal returns the shortest textual representation of v.
+func decimal(v float64) string { return strconv.FormatFloat(v, 'f', -1, 64) }
+
+// text returns the textual representation of the numeric point p.
+func text(p Point) string {
+	if v, ok := p.Float(); ok {
+		return decimal(v)
+	}
+	return ""
+}
+
+// setText sets the numeric point p from its textual representation.
+func setText(p Point, s string) error {
+	if s == "" {
+		p.Invalidate()
+		return nil
+	}
+	v, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return fmt.Errorf("%w: %q is not a number for point %q", ErrIllegalValue, s, p.Name())
+	}
+	return p.SetFloat(v)
+}
+
+// nonNumeric is the error returned when setting a non-numeric point to a number.
+func nonNumeric(p Point) error {
+	return fmt.Errorf("%w: point %q is not numeric", ErrIllegalValue, p.Name())
+}